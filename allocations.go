@@ -0,0 +1,93 @@
+package bdc
+
+import "fmt"
+
+// InvoiceAllocation applies part of a payment or credit to a single invoice
+type InvoiceAllocation struct {
+	InvoiceID string
+	Amount    Money
+}
+
+// BillAllocation applies part of a payment or credit to a single bill
+type BillAllocation struct {
+	BillID string
+	Amount Money
+}
+
+// validateInvoiceAllocations checks that each allocation is positive and no greater than the amount due
+// on an active invoice belonging to the customer, and that together they do not exceed limit.
+// No allocations is valid (eg an unapplied payment); callers that require one must check for it.
+// Returns the invoices allocated to, by ID
+func (c *Client) validateInvoiceAllocations(customerID string, limit Money, allocations []InvoiceAllocation) (map[string]Invoice, error) {
+	var total Money
+	byInvoice := make(map[string]Money)
+	var order []string
+	for _, a := range allocations {
+		if a.Amount <= 0 {
+			return nil, fmt.Errorf("allocation to invoice %s must be greater than 0", a.InvoiceID)
+		}
+		if _, ok := byInvoice[a.InvoiceID]; !ok {
+			order = append(order, a.InvoiceID)
+		}
+		byInvoice[a.InvoiceID] += a.Amount
+		total += a.Amount
+	}
+	if total > limit {
+		return nil, fmt.Errorf("allocations total %v, which exceeds the %v available", total, limit)
+	}
+	invoices := make(map[string]Invoice)
+	for _, id := range order {
+		inv, err := c.Invoice.Get(id)
+		if err != nil {
+			return nil, fmt.Errorf("unable to check allocation: %v", err)
+		}
+		if inv.CustomerID != customerID {
+			return nil, fmt.Errorf("invoice %s belongs to customer %s, not %s", id, inv.CustomerID, customerID)
+		}
+		if inv.IsActive != Active {
+			return nil, fmt.Errorf("invoice %s is inactive", id)
+		}
+		if byInvoice[id] > inv.AmountDue {
+			return nil, fmt.Errorf("allocation of %v to invoice %s exceeds its amount due of %v", byInvoice[id], id, inv.AmountDue)
+		}
+		invoices[id] = inv
+	}
+	return invoices, nil
+}
+
+// validateBillAllocations checks that each allocation is positive and no greater than the amount due on an active bill.
+// If vendorID is not empty, every bill must also belong to that vendor.
+// Returns the bills allocated to, by ID
+func (c *Client) validateBillAllocations(vendorID string, allocations []BillAllocation) (map[string]Bill, error) {
+	if len(allocations) == 0 {
+		return nil, fmt.Errorf("must provide at least one bill")
+	}
+	byBill := make(map[string]Money)
+	bills := make(map[string]Bill)
+	for _, a := range allocations {
+		if a.Amount <= 0 {
+			return nil, fmt.Errorf("allocation to bill %s must be greater than 0", a.BillID)
+		}
+		byBill[a.BillID] += a.Amount
+		if _, ok := bills[a.BillID]; ok {
+			continue
+		}
+		bill, err := c.Bill.Get(a.BillID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to check allocation: %v", err)
+		}
+		if vendorID != "" && bill.VendorID != vendorID {
+			return nil, fmt.Errorf("bill %s belongs to vendor %s, not %s", a.BillID, bill.VendorID, vendorID)
+		}
+		if bill.IsActive != Active {
+			return nil, fmt.Errorf("bill %s is inactive", a.BillID)
+		}
+		bills[a.BillID] = bill
+	}
+	for id, amount := range byBill {
+		if amount > bills[id].AmountDue {
+			return nil, fmt.Errorf("allocation of %v to bill %s exceeds its amount due of %v", amount, id, bills[id].AmountDue)
+		}
+	}
+	return bills, nil
+}
@@ -0,0 +1,142 @@
+package bdc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	listUserApprovalsEndpoint = "ListUserApprovals.json"
+	listApproversEndpoint     = "ListApprovers.json"
+	setApproversEndpoint      = "SetApprovers.json"
+	approveEndpoint           = "Approve.json"
+	denyEndpoint              = "Deny.json"
+)
+
+// Approver of a bill in Bill.com
+type Approver struct {
+	Entity            string         `json:"entity"`
+	ID                string         `json:"id"`
+	UserID            string         `json:"usersId"`
+	SortOrder         int            `json:"sortOrder"`
+	Status            ApproverStatus `json:"status"`
+	StatusChangedDate Timestamp      `json:"statusChangedDate"`
+	CreatedTime       Timestamp      `json:"createdTime"`
+	UpdatedTime       Timestamp      `json:"updatedTime"`
+}
+
+// StaleApproval is a bill that has been waiting on the same approver for too long
+type StaleApproval struct {
+	Bill     Bill
+	Approver Approver // the approver the bill is currently waiting on
+	Since    time.Time
+}
+
+// approvals of bills
+type approvalResource struct {
+	client *Client
+}
+
+// Pending returns the bills awaiting approval by the logged-in user
+func (r approvalResource) Pending() ([]Bill, error) {
+	var bills []Bill
+	for start := 0; ; start += pageMax {
+		var page []Bill
+		values := map[string]interface{}{"start": start, "max": pageMax, "entity": "Bill"}
+		err := r.client.call(listUserApprovalsEndpoint, values, &page)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to list pending approvals: %v", err)
+		}
+		bills = append(bills, page...)
+		if len(page) < pageMax {
+			return bills, nil
+		}
+	}
+}
+
+// Approvers returns the approvers of a bill, in approval order
+func (r approvalResource) Approvers(billID string) ([]Approver, error) {
+	var approvers []Approver
+	values := map[string]interface{}{"objectId": billID, "entity": "Bill"}
+	err := r.client.call(listApproversEndpoint, values, &approvers)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to list approvers for bill %s: %v", billID, err)
+	}
+	return approvers, nil
+}
+
+// SetApprovers replaces the approvers of a bill with the user IDs provided, in approval order
+func (r approvalResource) SetApprovers(billID string, userIDs []string) error {
+	values := map[string]interface{}{"objectId": billID, "entity": "Bill", "approvers": userIDs}
+	err := r.client.mutate(setApproversEndpoint, values, nil)
+	if err != nil {
+		return fmt.Errorf("Unable to set approvers for bill %s: %v", billID, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Set approvers for bill %s to %v", billID, userIDs))
+	return nil
+}
+
+// Approve a bill as the logged-in user
+func (r approvalResource) Approve(billID, comment string) error {
+	values := map[string]interface{}{"objectId": billID, "comment": comment}
+	err := r.client.mutate(approveEndpoint, values, nil)
+	if err != nil {
+		return fmt.Errorf("Unable to approve bill %s: %v", billID, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Approved bill %s: %s", billID, comment))
+	return nil
+}
+
+// Deny a bill as the logged-in user
+func (r approvalResource) Deny(billID, comment string) error {
+	values := map[string]interface{}{"objectId": billID, "comment": comment}
+	err := r.client.mutate(denyEndpoint, values, nil)
+	if err != nil {
+		return fmt.Errorf("Unable to deny bill %s: %v", billID, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Denied bill %s: %s", billID, comment))
+	return nil
+}
+
+// Stale returns the active bills that have been waiting on their current approver for longer than days,
+// longest-waiting first
+func (r approvalResource) Stale(days int) ([]StaleApproval, error) {
+	p := NewParameters()
+	p.AddFilter("isActive", "=", Active)
+	p.AddFilter("approvalStatus", "in", strings.Join([]string{string(ApprovalAssigned), string(ApprovalApproving)}, ","))
+	bills, err := r.client.Bill.All(p)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to find stale approvals: %v", err)
+	}
+	cutoff := time.Now().AddDate(0, 0, -days)
+	var stale []StaleApproval
+	for _, bill := range bills {
+		approvers, err := r.Approvers(bill.ID)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to find stale approvals: %v", err)
+		}
+		for _, approver := range approvers {
+			if approver.Status != ApproverWaiting {
+				continue
+			}
+			since := approver.StatusChangedDate.Time
+			if since.IsZero() {
+				// fall back to the last time the bill itself changed
+				since = bill.UpdatedTime.Time
+				if since.IsZero() {
+					return nil, fmt.Errorf("Unable to find stale approvals: unable to tell how long bill %s has been waiting", bill.ID)
+				}
+			}
+			if since.Before(cutoff) {
+				stale = append(stale, StaleApproval{Bill: bill, Approver: approver, Since: since})
+			}
+			break
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool {
+		return stale[i].Since.Before(stale[j].Since)
+	})
+	return stale, nil
+}
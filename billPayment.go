@@ -10,24 +10,41 @@ type paymentMadeResponse struct {
 	Data []PaymentMade `json:"response_data"`
 }
 
+type paymentMadeResp struct {
+	Data PaymentMade `json:"response_data"`
+}
+
 // PaymentMade in Bill.com
 type PaymentMade struct {
-	Entity        string  `json:"entity"`
-	ID            string  `json:"id"`
-	BillID        string  `json:"billId"`
-	Name          string  `json:"name"`
-	PaymentStatus string  `json:"paymentStatus"`
-	Amount        float64 `json:"amount"`
-	Description   string  `json:"description"`
-	ProcessDate   string  `json:"processDate"`
-	CreatedTime   string  `json:"createdTime"`
-	UpdatedTime   string  `json:"updatedTime"`
+	Entity        string    `json:"entity"`
+	ID            string    `json:"id"`
+	BillID        string    `json:"billId"`
+	Name          string    `json:"name"`
+	PaymentStatus string    `json:"paymentStatus"`
+	Amount        Money     `json:"amount"`
+	Description   string    `json:"description"`
+	ProcessDate   Date      `json:"processDate"`
+	CreatedTime   Timestamp `json:"createdTime"`
+	UpdatedTime   Timestamp `json:"updatedTime"`
 }
 
 type paymentMadeResource struct {
 	resourceFields
 }
 
+// Get returns a single PaymentMade object
+func (r paymentMadeResource) Get(id string) (PaymentMade, error) {
+	payment, err := r.client.getOne(r.suffix, id)
+	if err != nil {
+		return PaymentMade{}, fmt.Errorf("Unable to get payment made id %v: %v", id, err)
+	}
+	var goodResp paymentMadeResp
+	if err := json.Unmarshal(payment, &goodResp); err != nil {
+		return PaymentMade{}, fmt.Errorf("Unable to read payment made id %v: %v", id, err)
+	}
+	return goodResp.Data, nil
+}
+
 // All bill payments
 func (r paymentMadeResource) All(parameters ...*Parameters) ([]PaymentMade, error) {
 	results := r.client.getAll(r.suffix, parameters)
@@ -39,7 +56,10 @@ func (r paymentMadeResource) All(parameters ...*Parameters) ([]PaymentMade, erro
 			errSlice = append(errSlice, fmt.Sprintf("Error on page %v: %v", resp.page, resp.err))
 		} else {
 			var goodResp paymentMadeResponse
-			json.Unmarshal(resp.result, &goodResp)
+			if err := json.Unmarshal(resp.result, &goodResp); err != nil {
+				errSlice = append(errSlice, fmt.Sprintf("Unable to read page %v: %v", resp.page, err))
+				continue
+			}
 			retList = append(retList, goodResp.Data...)
 		}
 	}
@@ -77,3 +97,145 @@ func (r paymentMadeResource) SinceFileTime(filePath string, params *Parameters)
 	}
 	return payments, nil
 }
+
+const (
+	payBillsEndpoint      = "PayBills.json"
+	payBillEndpoint       = "PayBill.json"
+	cancelPaymentEndpoint = "CancelAPPayment.json"
+)
+
+// PayBill schedules a payment from a funding bank account for a single bill.
+// Requires multi-factor authentication.
+// Returns the payment as stored by Bill.com
+func (r paymentMadeResource) PayBill(bankAccountID string, processDate Date, allocation BillAllocation) (PaymentMade, error) {
+	if processDate.IsZero() {
+		return PaymentMade{}, fmt.Errorf("Unable to pay bill %s: must provide a process date", allocation.BillID)
+	}
+	bills, err := r.client.validateBillAllocations("", []BillAllocation{allocation})
+	if err != nil {
+		return PaymentMade{}, fmt.Errorf("Unable to pay bill %s: %v", allocation.BillID, err)
+	}
+	if err := r.client.requireMFA(); err != nil {
+		return PaymentMade{}, fmt.Errorf("Unable to pay bill %s: %v", allocation.BillID, err)
+	}
+	values := map[string]interface{}{
+		"billId":        allocation.BillID,
+		"bankAccountId": bankAccountID,
+		"processDate":   processDate,
+		"amount":        allocation.Amount,
+	}
+	var payment PaymentMade
+	err = r.client.mutate(payBillEndpoint, values, &payment)
+	if err != nil {
+		return PaymentMade{}, fmt.Errorf("Unable to pay bill %s in amount %v: %v", allocation.BillID, allocation.Amount, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Paid bill %s from vendor %s in amount %v on %s from bank account %s (payment %s)",
+		allocation.BillID, bills[allocation.BillID].VendorID, allocation.Amount, processDate, bankAccountID, payment.ID))
+	return payment, nil
+}
+
+// PayBills schedules a single payment to a vendor from a funding bank account covering one or more of its bills.
+// Requires multi-factor authentication.
+// Each allocation may not exceed the amount due on its bill.
+// Returns the payment as stored by Bill.com
+func (r paymentMadeResource) PayBills(vendorID, bankAccountID string, processDate Date, allocations []BillAllocation) (PaymentMade, error) {
+	if processDate.IsZero() {
+		return PaymentMade{}, fmt.Errorf("Unable to pay bills for vendor %s: must provide a process date", vendorID)
+	}
+	_, err := r.client.validateBillAllocations(vendorID, allocations)
+	if err != nil {
+		return PaymentMade{}, fmt.Errorf("Unable to pay bills for vendor %s: %v", vendorID, err)
+	}
+	if err := r.client.requireMFA(); err != nil {
+		return PaymentMade{}, fmt.Errorf("Unable to pay bills for vendor %s: %v", vendorID, err)
+	}
+	return r.payVendor(vendorID, bankAccountID, processDate, allocations)
+}
+
+// PaymentRun pays every bill allocation provided from a funding bank account, eg as part of a weekly payment run.
+// Allocations are grouped into one payment per vendor.
+// Every allocation is validated before any payment is sent. Requires multi-factor authentication.
+// Continues past failures; returns the payments that succeeded and all failures together
+func (r paymentMadeResource) PaymentRun(bankAccountID string, processDate Date, allocations []BillAllocation) ([]PaymentMade, error) {
+	if processDate.IsZero() {
+		return nil, fmt.Errorf("Unable to start payment run: must provide a process date")
+	}
+	bills, err := r.client.validateBillAllocations("", allocations)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to start payment run: %v", err)
+	}
+	// authenticate only once every allocation is known to be valid, so that a bad request never prompts for a token
+	if err := r.client.requireMFA(); err != nil {
+		return nil, fmt.Errorf("Unable to start payment run: %v", err)
+	}
+	byVendor := make(map[string][]BillAllocation)
+	var vendors []string
+	for _, a := range allocations {
+		vendorID := bills[a.BillID].VendorID
+		if _, ok := byVendor[vendorID]; !ok {
+			vendors = append(vendors, vendorID)
+		}
+		byVendor[vendorID] = append(byVendor[vendorID], a)
+	}
+
+	var payments []PaymentMade
+	var errSlice []string
+	for _, vendorID := range vendors {
+		payment, err := r.payVendor(vendorID, bankAccountID, processDate, byVendor[vendorID])
+		if err != nil {
+			errSlice = append(errSlice, err.Error())
+			continue
+		}
+		payments = append(payments, payment)
+	}
+	err = handleErrSlice(errSlice)
+	if err != nil {
+		return payments, fmt.Errorf("Unable to pay %d of %d vendors:\n%v", len(errSlice), len(vendors), err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Completed payment run of %d payments on %s from bank account %s", len(payments), processDate, bankAccountID))
+	return payments, nil
+}
+
+// send a PayBills request for one vendor; allocations must already be validated
+func (r paymentMadeResource) payVendor(vendorID, bankAccountID string, processDate Date, allocations []BillAllocation) (PaymentMade, error) {
+	var billPays []map[string]interface{}
+	var total Money
+	for _, a := range allocations {
+		billPays = append(billPays, map[string]interface{}{
+			"billId": a.BillID,
+			"amount": a.Amount,
+		})
+		total += a.Amount
+	}
+	values := map[string]interface{}{
+		"vendorId":      vendorID,
+		"bankAccountId": bankAccountID,
+		"processDate":   processDate,
+		"billPays":      billPays,
+	}
+	var payment PaymentMade
+	err := r.client.mutate(payBillsEndpoint, values, &payment)
+	if err != nil {
+		return PaymentMade{}, fmt.Errorf("Unable to pay bills for vendor %s in amount %v: %v", vendorID, total, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Paid vendor %s in amount %v on %s from bank account %s for bills %v (payment %s)",
+		vendorID, total, processDate, bankAccountID, allocations, payment.ID))
+	return payment, nil
+}
+
+// Cancel voids a scheduled or processing payment made.
+// Payments that have already been disbursed cannot be canceled. Requires multi-factor authentication
+func (r paymentMadeResource) Cancel(id string) error {
+	if id == "" {
+		return fmt.Errorf("Must provide payment ID to cancel")
+	}
+	if err := r.client.requireMFA(); err != nil {
+		return fmt.Errorf("Unable to cancel payment %s: %v", id, err)
+	}
+	err := r.client.mutate(cancelPaymentEndpoint, map[string]interface{}{"sentPayId": id}, nil)
+	if err != nil {
+		return fmt.Errorf("Unable to cancel payment %s: %v", id, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Canceled payment %s", id))
+	return nil
+}
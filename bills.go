@@ -10,27 +10,35 @@ type billResponse struct {
 	Data []Bill `json:"response_data"`
 }
 
+type billResp struct {
+	Data Bill `json:"response_data"`
+}
+
 // Bill in Bill.com
 type Bill struct {
-	Entity        string `json:"entity"`
-	CreatedTime   string `json:"createdTime"`
-	UpdatedTime   string `json:"updatedTime"`
-	IsActive      string `json:"isActive"`
-	VendorID      string `json:"vendorId"`
-	ID            string `json:"id"`
-	InvoiceNumber string `json:"invoiceNumber"`
-	InvoiceDate   string `json:"invoiceDate"`
-	DueDate       string `json:"dueDate"`
-	Description   string `json:"description"`
-	LineItems     []struct {
-		Entity      string  `json:"entity"`
-		Amount      float64 `json:"amount"`
-		ItemID      string  `json:"itemId"`
-		Quantity    int     `json:"quantity"`
-		Price       float64 `json:"unitPrice"`
-		BillID      string  `json:"actgBillId"`
-		LocationID  string  `json:"locationId"`
-		Description string  `json:"description"`
+	Entity         string               `json:"entity"`
+	CreatedTime    Timestamp            `json:"createdTime"`
+	UpdatedTime    Timestamp            `json:"updatedTime"`
+	IsActive       ActiveStatus         `json:"isActive"`
+	VendorID       string               `json:"vendorId"`
+	ID             string               `json:"id"`
+	InvoiceNumber  string               `json:"invoiceNumber"`
+	InvoiceDate    Date                 `json:"invoiceDate"`
+	DueDate        Date                 `json:"dueDate"`
+	Description    string               `json:"description"`
+	Amount         Money                `json:"amount"`
+	AmountDue      Money                `json:"amountDue"`
+	PaymentStatus  InvoicePaymentStatus `json:"paymentStatus"`
+	ApprovalStatus ApprovalStatus       `json:"approvalStatus"`
+	LineItems      []struct {
+		Entity      string `json:"entity"`
+		Amount      Money  `json:"amount"`
+		ItemID      string `json:"itemId"`
+		Quantity    int    `json:"quantity"`
+		Price       Money  `json:"unitPrice"`
+		BillID      string `json:"actgBillId"`
+		LocationID  string `json:"locationId"`
+		Description string `json:"description"`
 	} `json:"billLineItems"`
 }
 
@@ -38,6 +46,19 @@ type billResource struct {
 	resourceFields
 }
 
+// Get returns a single Bill object
+func (r billResource) Get(id string) (Bill, error) {
+	bill, err := r.client.getOne(r.suffix, id)
+	if err != nil {
+		return Bill{}, fmt.Errorf("Unable to get bill id %v: %v", id, err)
+	}
+	var goodResp billResp
+	if err := json.Unmarshal(bill, &goodResp); err != nil {
+		return Bill{}, fmt.Errorf("Unable to read bill id %v: %v", id, err)
+	}
+	return goodResp.Data, nil
+}
+
 // All bills
 func (r billResource) All(parameters ...*Parameters) ([]Bill, error) {
 	results := r.client.getAll(r.suffix, parameters)
@@ -49,7 +70,10 @@ func (r billResource) All(parameters ...*Parameters) ([]Bill, error) {
 			errSlice = append(errSlice, fmt.Sprintf("Error on page %v: %v", resp.page, resp.err))
 		} else {
 			var goodResp billResponse
-			json.Unmarshal(resp.result, &goodResp)
+			if err := json.Unmarshal(resp.result, &goodResp); err != nil {
+				errSlice = append(errSlice, fmt.Sprintf("Unable to read page %v: %v", resp.page, err))
+				continue
+			}
 			retList = append(retList, goodResp.Data...)
 		}
 	}
@@ -71,3 +95,24 @@ func (r billResource) Since(t time.Time, p *Parameters) ([]Bill, error) {
 	}
 	return bills, nil
 }
+
+// Update one bill.
+// Supply the bill ID and only the fields to change, eg bdc.Set("description", "");
+// all other fields will be preserved. Zero and empty values are applied as is.
+// To guard against overwriting someone else's changes, include bdc.IfUnchanged(base).
+// Returns the updated bill as stored by Bill.com
+func (r billResource) Update(id string, fields ...Field) (Bill, error) {
+	var updated Bill
+	changes, err := r.client.patchEntity(r.suffix, id, fields, &updated)
+	if err != nil {
+		if conflict, ok := err.(*ConflictError); ok {
+			return Bill{}, conflict
+		}
+		if unreadable, ok := err.(*UnreadableResultError); ok {
+			return Bill{}, unreadable
+		}
+		return Bill{}, fmt.Errorf("Unable to update bill %v: %v", id, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Updated bill %s: %s", updated.ID, changes))
+	return updated, nil
+}
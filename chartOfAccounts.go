@@ -0,0 +1,83 @@
+package bdc
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type chartOfAccountResponse struct {
+	Data []ChartOfAccount `json:"response_data"`
+}
+
+type chartOfAccountResp struct {
+	Data ChartOfAccount `json:"response_data"`
+}
+
+// ChartOfAccount is an account in the chart of accounts in Bill.com (matches the general ledger)
+type ChartOfAccount struct {
+	Entity        string       `json:"entity"`
+	CreatedTime   Timestamp    `json:"createdTime"`
+	UpdatedTime   Timestamp    `json:"updatedTime"`
+	IsActive      ActiveStatus `json:"isActive"`
+	ID            string       `json:"id"`
+	Name          string       `json:"name"`
+	Description   string       `json:"description"`
+	AccountType   int          `json:"accountType"`
+	AccountNumber string       `json:"accountNumber"`
+	ParentID      string       `json:"parentChartOfAccountId"`
+}
+
+type chartOfAccountResource struct {
+	resourceFields
+}
+
+// Get returns a single ChartOfAccount object
+func (r chartOfAccountResource) Get(id string) (ChartOfAccount, error) {
+	b, err := r.client.getOne(r.suffix, id)
+	if err != nil {
+		return ChartOfAccount{}, fmt.Errorf("Unable to get chart of accounts entry id %v: %v", id, err)
+	}
+	var goodResp chartOfAccountResp
+	if err := json.Unmarshal(b, &goodResp); err != nil {
+		return ChartOfAccount{}, fmt.Errorf("Unable to read chart of accounts entry id %v: %v", id, err)
+	}
+	return goodResp.Data, nil
+}
+
+// All chart of accounts entries
+func (r chartOfAccountResource) All(parameters ...*Parameters) ([]ChartOfAccount, error) {
+	results := r.client.getAll(r.suffix, parameters)
+
+	var retList []ChartOfAccount
+	var errSlice []string
+	for _, resp := range results {
+		if resp.err != nil {
+			errSlice = append(errSlice, fmt.Sprintf("Error on page %v: %v", resp.page, resp.err))
+		} else {
+			var goodResp chartOfAccountResponse
+			if err := json.Unmarshal(resp.result, &goodResp); err != nil {
+				errSlice = append(errSlice, fmt.Sprintf("Unable to read page %v: %v", resp.page, err))
+				continue
+			}
+			retList = append(retList, goodResp.Data...)
+		}
+	}
+	err := handleErrSlice(errSlice)
+
+	return retList, err
+}
+
+// Since returns all chart of accounts entries updated since the time provided.
+// If no additional params to provide, must pass nil explicitly
+func (r chartOfAccountResource) Since(t time.Time, p *Parameters) ([]ChartOfAccount, error) {
+	if p == nil {
+		p = NewParameters()
+	}
+	p.AddFilter("updatedTime", ">", t.Format(TimeFormat))
+	results, err := r.client.ChartOfAccount.All(p)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get all chart of accounts entries updated since %s: %v", t, err)
+	}
+	return results, nil
+}
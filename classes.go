@@ -12,14 +12,14 @@ type classResponse struct {
 
 // Class is an accounting class in Bill.com (matches QBO class)
 type Class struct {
-	Entity      string `json:"entity"`
-	CreatedTime string `json:"createdTime"`
-	UpdatedTime string `json:"updatedTime"`
-	IsActive    string `json:"isActive"`
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	ShortName   string `json:"shortName"`
-	Description string `json:"description"`
+	Entity      string       `json:"entity"`
+	CreatedTime Timestamp    `json:"createdTime"`
+	UpdatedTime Timestamp    `json:"updatedTime"`
+	IsActive    ActiveStatus `json:"isActive"`
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	ShortName   string       `json:"shortName"`
+	Description string       `json:"description"`
 }
 
 type classResource struct {
@@ -37,7 +37,10 @@ func (r classResource) All(parameters ...*Parameters) ([]Class, error) {
 			errSlice = append(errSlice, fmt.Sprintf("Error on page %v: %v", resp.page, resp.err))
 		} else {
 			var goodResp classResponse
-			json.Unmarshal(resp.result, &goodResp)
+			if err := json.Unmarshal(resp.result, &goodResp); err != nil {
+				errSlice = append(errSlice, fmt.Sprintf("Unable to read page %v: %v", resp.page, err))
+				continue
+			}
 			retList = append(retList, goodResp.Data...)
 		}
 	}
@@ -59,3 +62,24 @@ func (r classResource) Since(t time.Time, p *Parameters) ([]Class, error) {
 	}
 	return classes, nil
 }
+
+// Update one class.
+// Supply the class ID and only the fields to change, eg bdc.Set("description", "");
+// all other fields will be preserved. Zero and empty values are applied as is.
+// To guard against overwriting someone else's changes, include bdc.IfUnchanged(base).
+// Returns the updated class as stored by Bill.com
+func (r classResource) Update(id string, fields ...Field) (Class, error) {
+	var updated Class
+	changes, err := r.client.patchEntity(r.suffix, id, fields, &updated)
+	if err != nil {
+		if conflict, ok := err.(*ConflictError); ok {
+			return Class{}, conflict
+		}
+		if unreadable, ok := err.(*UnreadableResultError); ok {
+			return Class{}, unreadable
+		}
+		return Class{}, fmt.Errorf("Unable to update class %v: %v", id, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Updated class %s: %s", updated.ID, changes))
+	return updated, nil
+}
@@ -1,10 +1,15 @@
 package bdc
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -16,15 +21,25 @@ const (
 )
 
 const (
-	customerSuffix        = "Customer.json"
-	vendorSuffix          = "Vendor.json"
-	invoiceSuffix         = "Invoice.json"
-	billSuffix            = "Bill.json"
-	paymentMadeSuffix     = "BillPay.json"
-	paymentReceivedSuffix = "ReceivedPay.json"
-	locationSuffix        = "Location.json"
-	classSuffix           = "ActgClass.json"
-	itemSuffix            = "Item.json"
+	customerSuffix         = "Customer.json"
+	vendorSuffix           = "Vendor.json"
+	invoiceSuffix          = "Invoice.json"
+	billSuffix             = "Bill.json"
+	paymentMadeSuffix      = "BillPay.json"
+	paymentReceivedSuffix  = "ReceivedPay.json"
+	locationSuffix         = "Location.json"
+	classSuffix            = "ActgClass.json"
+	itemSuffix             = "Item.json"
+	chartOfAccountSuffix   = "ChartOfAccount.json"
+	departmentSuffix       = "Department.json"
+	jobSuffix              = "Job.json"
+	employeeSuffix         = "Employee.json"
+	customerContactSuffix  = "CustomerContact.json"
+	customerBankSuffix     = "CustomerBankAccount.json"
+	creditMemoSuffix       = "CreditMemo.json"
+	vendorCreditSuffix     = "VendorCredit.json"
+	recurringInvoiceSuffix = "RecurringInvoice.json"
+	recurringBillSuffix    = "RecurringBill.json"
 )
 
 type credentials struct {
@@ -36,22 +51,46 @@ type credentials struct {
 
 // A Client for making authenticated API calls
 type Client struct {
-	sessionID       string
-	devKey          string
-	expiresAt       time.Time
-	Reports         reports
-	Customer        customerResource
-	Vendor          vendorResource
-	Invoice         invoiceResource
-	Bill            billResource
-	PaymentMade     paymentMadeResource
-	PaymentReceived paymentReceivedResource
-	Location        locationResource
-	Class           classResource
-	Item            itemResource
-}
-
-func (c Client) expired() bool {
+	sessionID           string
+	devKey              string
+	userID              string
+	expiresAt           time.Time
+	plan                *Plan // non-nil in dry-run mode
+	creds               credentials
+	mappingsDir         string
+	mfaID               string       // guarded by mu
+	mfaDeviceID         string       // guarded by mu
+	mfaHandler          MFATokenFunc // guarded by mu
+	mfaMachineName      string       // for mfaHandler; guarded by mu
+	mu                  sync.Mutex
+	closed              bool                      // set by c.Close()
+	inflight            sync.WaitGroup            // requests in progress, for c.Close()
+	metadata            map[string]EntityMetadata // cached by c.Metadata()
+	Reports             reports
+	Approval            approvalResource
+	Documents           documentResource
+	Customer            customerResource
+	Vendor              vendorResource
+	Invoice             invoiceResource
+	Bill                billResource
+	PaymentMade         paymentMadeResource
+	PaymentReceived     paymentReceivedResource
+	Location            locationResource
+	Class               classResource
+	Item                itemResource
+	ChartOfAccount      chartOfAccountResource
+	Department          departmentResource
+	Job                 jobResource
+	Employee            employeeResource
+	CustomerContact     customerContactResource
+	CustomerBankAccount customerBankAccountResource
+	CreditMemo          creditMemoResource
+	VendorCredit        vendorCreditResource
+	RecurringInvoice    recurringInvoiceResource
+	RecurringBill       recurringBillResource
+}
+
+func (c *Client) expired() bool {
 	if time.Now().After(c.expiresAt) {
 		return true
 	}
@@ -78,6 +117,10 @@ type confirmationResponse struct {
 	Data map[string]interface{} `json:"response_data"`
 }
 
+type entityResponse struct {
+	Data json.RawMessage `json:"response_data"`
+}
+
 // TimeFormat is the format Bill.com uses for times
 const TimeFormat = "2006-01-02T15:04:05.999-0700"
 
@@ -90,33 +133,89 @@ var client = new(Client)
 // Must provide the path to a JSON file containing complete Bill.com credentials.
 func NewClient() (*Client, error) {
 	loadConfig()
-	if client.sessionID == "" || client.expired() {
+	if client.sessionID == "" || client.expired() || client.isClosed() {
 		var creds credentials
-		sid, err := login(&creds)
+		session, err := login(&creds)
 		if err != nil {
 			return nil, fmt.Errorf("Unable to create new Client: %s", err)
 		}
-		client = &Client{sessionID: sid, devKey: creds.DevKey, expiresAt: time.Now().Add(5 * time.Minute)}
-	}
-
-	client.Reports = reports{client: client}
-	client.Customer = customerResource{resourceFields{suffix: customerSuffix, client: client}}
-	client.Vendor = vendorResource{resourceFields{suffix: vendorSuffix, client: client}}
-	client.Invoice = invoiceResource{resourceFields{suffix: invoiceSuffix, client: client}}
-	client.Bill = billResource{resourceFields{suffix: billSuffix, client: client}}
-	client.PaymentMade = paymentMadeResource{resourceFields{suffix: paymentMadeSuffix, client: client}}
-	client.PaymentReceived = paymentReceivedResource{resourceFields{suffix: paymentReceivedSuffix, client: client}}
-	client.Location = locationResource{resourceFields{suffix: locationSuffix, client: client}}
-	client.Class = classResource{resourceFields{suffix: classSuffix, client: client}}
-	client.Item = itemResource{resourceFields{suffix: itemSuffix, client: client}}
+		client = newSessionClient(session, creds, mappingsDir)
+	}
+	client.attachResources()
 	return client, nil
 }
 
+// newSessionClient returns a client for a logged-in session
+func newSessionClient(s session, creds credentials, dir string) *Client {
+	return &Client{
+		sessionID:   s.SessionID,
+		devKey:      creds.DevKey,
+		userID:      s.UserID,
+		expiresAt:   time.Now().Add(5 * time.Minute),
+		creds:       creds,
+		mappingsDir: dir,
+		mfaID:       mfaID,
+		mfaDeviceID: mfaDeviceID,
+	}
+}
+
+// ForOrganization logs in to another organization with the same credentials as c
+// and returns an independent client with its own session.
+// Its mappings are stored in a subdirectory of the configured mappings directory named for the organization ID,
+// so use the client's methods (eg c.NewInvoice) rather than package-level functions to create invoices with custom values
+func (c *Client) ForOrganization(orgID string) (*Client, error) {
+	creds := c.creds
+	creds.OrgID = orgID
+	id, deviceID := c.mfaDevice()
+	session, err := loginWith(creds, id, deviceID)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to create Client for organization %s: %v", orgID, err)
+	}
+	orgClient := newSessionClient(session, creds, filepath.Join(mappingsDir, orgID))
+	orgClient.mfaID, orgClient.mfaDeviceID = id, deviceID
+	c.mu.Lock()
+	orgClient.mfaMachineName, orgClient.mfaHandler = c.mfaMachineName, c.mfaHandler
+	c.mu.Unlock()
+	orgClient.attachResources()
+	return orgClient, nil
+}
+
+// attach every resource to the client
+func (c *Client) attachResources() {
+	c.Reports = reports{client: c}
+	c.Approval = approvalResource{client: c}
+	c.Documents = documentResource{client: c}
+	c.Customer = customerResource{resourceFields{suffix: customerSuffix, client: c}}
+	c.Vendor = vendorResource{resourceFields{suffix: vendorSuffix, client: c}}
+	c.Invoice = invoiceResource{resourceFields{suffix: invoiceSuffix, client: c}}
+	c.Bill = billResource{resourceFields{suffix: billSuffix, client: c}}
+	c.PaymentMade = paymentMadeResource{resourceFields{suffix: paymentMadeSuffix, client: c}}
+	c.PaymentReceived = paymentReceivedResource{resourceFields{suffix: paymentReceivedSuffix, client: c}}
+	c.Location = locationResource{resourceFields{suffix: locationSuffix, client: c}}
+	c.Class = classResource{resourceFields{suffix: classSuffix, client: c}}
+	c.Item = itemResource{resourceFields{suffix: itemSuffix, client: c}}
+	c.ChartOfAccount = chartOfAccountResource{resourceFields{suffix: chartOfAccountSuffix, client: c}}
+	c.Department = departmentResource{resourceFields{suffix: departmentSuffix, client: c}}
+	c.Job = jobResource{resourceFields{suffix: jobSuffix, client: c}}
+	c.Employee = employeeResource{resourceFields{suffix: employeeSuffix, client: c}}
+	c.CustomerContact = customerContactResource{resourceFields{suffix: customerContactSuffix, client: c}}
+	c.CustomerBankAccount = customerBankAccountResource{resourceFields{suffix: customerBankSuffix, client: c}}
+	c.CreditMemo = creditMemoResource{resourceFields{suffix: creditMemoSuffix, client: c}}
+	c.VendorCredit = vendorCreditResource{resourceFields{suffix: vendorCreditSuffix, client: c}}
+	c.RecurringInvoice = recurringInvoiceResource{resourceFields{suffix: recurringInvoiceSuffix, client: c}}
+	c.RecurringBill = recurringBillResource{resourceFields{suffix: recurringBillSuffix, client: c}}
+}
+
 // Client Convenience Functions
 // make an HTTP request
 func makeRequest(endpoint string, body io.Reader) ([]byte, error) {
+	return makeRequestWithType(endpoint, "application/x-www-form-urlencoded", body)
+}
+
+// make an HTTP request with a body encoded as contentType, eg multipart/form-data
+func makeRequestWithType(endpoint string, contentType string, body io.Reader) ([]byte, error) {
 	url := baseURL + endpoint
-	resp, err := http.Post(url, "application/x-www-form-urlencoded", body)
+	resp, err := http.Post(url, contentType, body)
 	if err != nil {
 		return nil, fmt.Errorf("Unable to send Post request to %s: %s", url, err)
 	}
@@ -132,6 +231,72 @@ func makeRequest(endpoint string, body io.Reader) ([]byte, error) {
 	return r, nil
 }
 
+// send a request in the client's session, tracking it so that c.Close() can wait for it to finish
+func (c *Client) send(endpoint string, body io.Reader) ([]byte, error) {
+	return c.sendWithType(endpoint, "application/x-www-form-urlencoded", body)
+}
+
+// send a request in the client's session with a body encoded as contentType
+func (c *Client) sendWithType(endpoint string, contentType string, body io.Reader) ([]byte, error) {
+	if err := c.begin(); err != nil {
+		return nil, err
+	}
+	defer c.inflight.Done()
+	return makeRequestWithType(endpoint, contentType, body)
+}
+
+// sessionValues returns the form values that authenticate every request in the client's session,
+// including the MFA values if the session has been authenticated with MFA
+func (c *Client) sessionValues() url.Values {
+	data := url.Values{}
+	data.Set("sessionId", c.sessionID)
+	data.Set("devKey", c.devKey)
+	if id, deviceID := c.mfaDevice(); id != "" {
+		data.Set("mfaId", id)
+		data.Set("deviceId", deviceID)
+	}
+	return data
+}
+
+// convert JSON values into a URL string for any endpoint
+func encodeData(c *Client, values map[string]interface{}) (io.Reader, error) {
+	data := c.sessionValues()
+	jsonValues, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to encode data: %v", err)
+	}
+	data.Set("data", string(jsonValues))
+
+	body := strings.NewReader(data.Encode())
+	return body, nil
+}
+
+// call an endpoint that is not a standard Crud or List operation, eg "SendInvoice.json",
+// and decode the response data into result, if result is not nil
+func (c *Client) call(endpoint string, values map[string]interface{}, result interface{}) error {
+	body, err := encodeData(c, values)
+	if err != nil {
+		return err
+	}
+	r, err := c.send(endpoint, body)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	return decodeEntity(r, result)
+}
+
+// mutate calls an endpoint that writes to Bill.com.
+// In dry-run mode, records the call in the client's plan instead
+func (c *Client) mutate(endpoint string, values map[string]interface{}, result interface{}) error {
+	if plan := c.dryRunPlan(); plan != nil {
+		return c.planCall(plan, endpoint, values, result)
+	}
+	return c.call(endpoint, values, result)
+}
+
 // read a time from a file; for use with resource-specific SinceFileTime()
 func readTimeFromFile(filePath string) (time.Time, error) {
 	b, err := ioutil.ReadFile(filePath)
@@ -0,0 +1,50 @@
+package bdc
+
+import "fmt"
+
+const logoutEndpoint = "Logout.json"
+
+// Close ends the client's session.
+// New calls on the client are rejected with ErrClientClosed; calls already in progress are allowed to finish.
+// Once they have, Close waits for any history write in progress and logs out of Bill.com.
+// A subsequent NewClient() logs in again rather than reusing the closed session
+func (c *Client) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return ErrClientClosed
+	}
+	c.closed = true
+	c.mu.Unlock()
+
+	c.inflight.Wait()
+	historyMu.Lock()
+	historyMu.Unlock()
+
+	body, err := encodeData(c, map[string]interface{}{})
+	if err != nil {
+		return fmt.Errorf("Unable to log out: %v", err)
+	}
+	_, err = makeRequest(logoutEndpoint, body)
+	if err != nil {
+		return fmt.Errorf("Unable to log out: %v", err)
+	}
+	return nil
+}
+
+// begin registers a request in progress, unless the client is closed
+func (c *Client) begin() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return ErrClientClosed
+	}
+	c.inflight.Add(1)
+	return nil
+}
+
+func (c *Client) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
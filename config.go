@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 )
 
@@ -24,10 +25,13 @@ const (
 	mappingsDirectory            = "bdc_mappingsDir"
 	historyFile                  = "bdc_historyFile"
 	showHistorySelection         = "bdc_showHistory"
+	mfaIDKey                     = "bdc_mfaId"       // optional; written by c.AuthenticateMFA()
+	mfaDeviceIDKey               = "bdc_mfaDeviceId" // optional; written by c.AuthenticateMFA()
 )
 
 var credentialsPath, mappingsDir, historyPath string
 var showHistory bool
+var mfaID, mfaDeviceID string
 
 // SpecifyConfig sets a custom path to the bdc_config.json file
 // and overrides "./.bdc_config.json"
@@ -94,5 +98,36 @@ func loadConfig() error {
 	if !ok {
 		return fmt.Errorf("Value for %q in config file (%q) must be type bool", showHistorySelection, configPath)
 	}
+
+	// a remembered MFA device is optional
+	mfaID, _ = configVars[mfaIDKey].(string)
+	mfaDeviceID, _ = configVars[mfaDeviceIDKey].(string)
+	return nil
+}
+
+// saveMFAConfig adds a remembered MFA device to the config file, preserving all other values
+func saveMFAConfig(id, deviceID string) error {
+	b, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("cannot read config file at %v: %v", configPath, err)
+	}
+	var configVars map[pathKey]interface{}
+	err = json.Unmarshal(b, &configVars)
+	if err != nil {
+		return fmt.Errorf("%v file must have valid JSON: %v", configPath, err)
+	}
+	configVars[mfaIDKey] = id
+	configVars[mfaDeviceIDKey] = deviceID
+	b, _ = json.MarshalIndent(configVars, "", "    ")
+	err = ioutil.WriteFile(configPath, b, 0600)
+	if err != nil {
+		return fmt.Errorf("cannot write config file at %v: %v", configPath, err)
+	}
+	// WriteFile does not change the permissions of an existing file
+	err = os.Chmod(configPath, 0600)
+	if err != nil {
+		return fmt.Errorf("cannot restrict permissions of config file at %v: %v", configPath, err)
+	}
+	mfaID, mfaDeviceID = id, deviceID
 	return nil
 }
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/url"
 	"strings"
 )
 
@@ -33,51 +32,118 @@ func encodeCreateData(c *Client, entity interface{}) (io.Reader, error) {
 	values := map[string]interface{}{"obj": entity}
 
 	// encode payload as URL
-	data := url.Values{}
+	data := c.sessionValues()
 	jsonValues, err := json.Marshal(values)
 	if err != nil {
 		return nil, fmt.Errorf("Unable to encode data: unable to marshal cleaned json: %v", err)
 	}
 	data.Set("data", string(jsonValues))
-	data.Set("sessionId", c.sessionID)
-	data.Set("devKey", c.devKey)
 
 	body := strings.NewReader(data.Encode())
 	return body, nil
 }
 
-func (c *Client) updateEntity(suffix string, entity interface{}) (string, error) {
+// Update entity in Bill.com.
+// Decodes the updated entity returned by the server into result, which must be a pointer.
+// In dry-run mode, records the update in the client's plan instead
+func (c *Client) updateEntity(suffix string, entity interface{}, result interface{}) error {
+	if m, ok := c.cachedMetadata(suffix); ok {
+		if err := m.Validate(entity); err != nil {
+			return fmt.Errorf("Invalid entity: %v", err)
+		}
+	}
+	if plan := c.dryRunPlan(); plan != nil {
+		return c.planEntity(plan, "Update", suffix, entity, result)
+	}
 	endpoint := "Crud/Update/" + suffix
 	body, err := encodeCreateData(c, entity)
 	if err != nil {
-		return "", fmt.Errorf("Unable to update entity: %v", err)
+		return fmt.Errorf("Unable to update entity: %v", err)
+	}
+	r, err := c.send(endpoint, body)
+	if err != nil {
+		return fmt.Errorf("Unable to update item at %v: %v", suffix, err)
 	}
-	r, err := makeRequest(endpoint, body)
+	err = decodeEntity(r, result)
 	if err != nil {
-		return "", fmt.Errorf("Unable to update item at %v: %v", suffix, err)
+		id, _ := entity.(map[string]interface{})["id"].(string)
+		return c.unreadableResult("Updated", suffix, id, r, err)
 	}
-	var resp confirmationResponse
-	json.Unmarshal(r, &resp)
-	confirmation := fmt.Sprintf("%v", resp.Data)
-	return confirmation, nil
+	return nil
 
 }
 
-// Create entity in Bill.com
-func (c *Client) createEntity(suffix string, entity interface{}) (string, error) {
+// Create entity in Bill.com.
+// Decodes the new entity returned by the server (including its ID) into result, which must be a pointer.
+// In dry-run mode, records the create in the client's plan instead
+func (c *Client) createEntity(suffix string, entity interface{}, result interface{}) error {
+	if m, ok := c.cachedMetadata(suffix); ok {
+		if err := m.Validate(entity); err != nil {
+			return fmt.Errorf("Invalid entity: %v", err)
+		}
+	}
+	if plan := c.dryRunPlan(); plan != nil {
+		return c.planEntity(plan, "Create", suffix, entity, result)
+	}
 	endpoint := "Crud/Create/" + suffix
 
 	body, err := encodeCreateData(c, entity)
 	if err != nil {
-		return "", fmt.Errorf("Unable to update entity: %v", err)
+		return fmt.Errorf("Unable to create entity: %v", err)
+	}
+	r, err := c.send(endpoint, body)
+	if err != nil {
+		return fmt.Errorf("Unable to create entity at %v: %v", suffix, err)
+
+	}
+	err = decodeEntity(r, result)
+	if err != nil {
+		return c.unreadableResult("Created", suffix, "", r, err)
 	}
-	r, err := makeRequest(endpoint, body)
+	return nil
+}
+
+// unreadableResult records a write whose response could not be decoded in the history file
+// and returns it as an *UnreadableResultError, so that callers can tell it apart from a write that failed.
+// If id is empty, it is taken from the response, if possible
+func (c *Client) unreadableResult(action, suffix, id string, r []byte, err error) error {
+	if id == "" {
+		var resp confirmationResponse
+		json.Unmarshal(r, &resp)
+		id, _ = resp.Data["id"].(string)
+	}
+	unreadable := &UnreadableResultError{Action: action, Suffix: suffix, ID: id, Err: err}
+	c.writeToHistory(unreadable.Error())
+	return unreadable
+}
+
+// decodeEntity decodes the single entity in a confirmation response into result
+func decodeEntity(r []byte, result interface{}) error {
+	var resp entityResponse
+	err := json.Unmarshal(r, &resp)
 	if err != nil {
-		return "", fmt.Errorf("Unable to create entity at %v: %v", suffix, err)
+		return fmt.Errorf("Unable to read response: %v", err)
+	}
+	if len(resp.Data) == 0 {
+		return fmt.Errorf("No entity in response")
+	}
+	return json.Unmarshal(resp.Data, result)
+}
 
+// Deactivate entity in Bill.com. Bill.com never deletes entities; they are marked Inactive.
+// In dry-run mode, records the deactivation in the client's plan instead
+func (c *Client) deactivateEntity(suffix string, id string) error {
+	if id == "" {
+		return fmt.Errorf("Must provide ID to deactivate")
+	}
+	endpoint := "Crud/Delete/" + suffix
+	values := map[string]interface{}{"id": id}
+	if plan := c.dryRunPlan(); plan != nil {
+		return c.planCall(plan, endpoint, values, nil)
+	}
+	err := c.call(endpoint, values, nil)
+	if err != nil {
+		return fmt.Errorf("Unable to deactivate item %v at %v: %v", id, suffix, err)
 	}
-	var resp confirmationResponse
-	json.Unmarshal(r, &resp)
-	confirmation := fmt.Sprintf("%v", resp.Data)
-	return confirmation, nil
+	return nil
 }
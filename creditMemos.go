@@ -0,0 +1,171 @@
+package bdc
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type creditMemoResponse struct {
+	Data []CreditMemo `json:"response_data"`
+}
+
+type creditMemoResp struct {
+	Data CreditMemo `json:"response_data"`
+}
+
+// CreditMemo issued to a customer in Bill.com, eg for a refund
+type CreditMemo struct {
+	Entity        string               `json:"entity"`
+	ID            string               `json:"id"`
+	CreatedTime   Timestamp            `json:"createdTime"`
+	UpdatedTime   Timestamp            `json:"updatedTime"`
+	IsActive      ActiveStatus         `json:"isActive"`
+	CustomerID    string               `json:"customerId"`
+	RefNumber     string               `json:"refNumber"`
+	CreditDate    Date                 `json:"creditDate"`
+	Amount        Money                `json:"amount"`
+	AppliedAmount Money                `json:"appliedAmount"`
+	Description   string               `json:"description"`
+	LineItems     []CreditMemoLineItem `json:"creditMemoLineItems"`
+}
+
+// CreditMemoLineItem on a Bill.com credit memo
+type CreditMemoLineItem struct {
+	Entity      string `json:"entity"`
+	ItemID      string `json:"itemId"`
+	Quantity    int    `json:"quantity"`
+	Amount      Money  `json:"amount"`
+	Price       Money  `json:"price"`
+	ClassID     string `json:"actgClassId"`
+	LocationID  string `json:"locationId"`
+	Description string `json:"description"`
+}
+
+// Remaining is the amount of the credit not yet applied
+func (c CreditMemo) Remaining() Money {
+	return c.Amount - c.AppliedAmount
+}
+
+type creditMemoResource struct {
+	resourceFields
+}
+
+const applyCreditMemoEndpoint = "ApplyCreditMemo.json"
+
+// Get returns a single CreditMemo object
+func (r creditMemoResource) Get(id string) (CreditMemo, error) {
+	b, err := r.client.getOne(r.suffix, id)
+	if err != nil {
+		return CreditMemo{}, fmt.Errorf("Unable to get credit memo id %v: %v", id, err)
+	}
+	var goodResp creditMemoResp
+	if err := json.Unmarshal(b, &goodResp); err != nil {
+		return CreditMemo{}, fmt.Errorf("Unable to read credit memo id %v: %v", id, err)
+	}
+	return goodResp.Data, nil
+}
+
+// All credit memos
+func (r creditMemoResource) All(parameters ...*Parameters) ([]CreditMemo, error) {
+	results := r.client.getAll(r.suffix, parameters)
+
+	var retList []CreditMemo
+	var errSlice []string
+	for _, resp := range results {
+		if resp.err != nil {
+			errSlice = append(errSlice, fmt.Sprintf("Error on page %v: %v", resp.page, resp.err))
+		} else {
+			var goodResp creditMemoResponse
+			if err := json.Unmarshal(resp.result, &goodResp); err != nil {
+				errSlice = append(errSlice, fmt.Sprintf("Unable to read page %v: %v", resp.page, err))
+				continue
+			}
+			retList = append(retList, goodResp.Data...)
+		}
+	}
+	err := handleErrSlice(errSlice)
+
+	return retList, err
+}
+
+// Since returns all credit memos updated since the time provided.
+// If no additional params to provide, must pass nil explicitly
+func (r creditMemoResource) Since(t time.Time, p *Parameters) ([]CreditMemo, error) {
+	if p == nil {
+		p = NewParameters()
+	}
+	p.AddFilter("updatedTime", ">", t.Format(TimeFormat))
+	results, err := r.client.CreditMemo.All(p)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get all credit memos updated since %s: %v", t, err)
+	}
+	return results, nil
+}
+
+// Create a credit memo.
+// Returns the new credit memo as stored by Bill.com
+func (r creditMemoResource) Create(credit CreditMemo) (CreditMemo, error) {
+	if credit.CustomerID == "" {
+		return CreditMemo{}, fmt.Errorf("Unable to create credit memo: must provide customer ID")
+	}
+	credit.Entity = "CreditMemo"
+	var created CreditMemo
+	err := r.client.createEntity(r.suffix, credit, &created)
+	if err != nil {
+		if unreadable, ok := err.(*UnreadableResultError); ok {
+			return CreditMemo{}, unreadable
+		}
+		return CreditMemo{}, fmt.Errorf("Unable to create credit memo for customer %s in amount %v: %v", credit.CustomerID, credit.Amount, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Created credit memo %s for customer %s in amount %v", created.ID, created.CustomerID, created.Amount))
+	return created, nil
+}
+
+// Apply part or all of a credit memo to specific invoices of the same customer.
+// Each allocation may not exceed the amount due on its invoice,
+// and all allocations together may not exceed the credit remaining.
+// Returns the invoices as stored by Bill.com after the credit is applied, so that AmountDue reflects it
+func (r creditMemoResource) Apply(id string, allocations []InvoiceAllocation) ([]Invoice, error) {
+	credit, err := r.Get(id)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to apply credit memo %s: %v", id, err)
+	}
+	if credit.IsActive != Active {
+		return nil, fmt.Errorf("Unable to apply credit memo %s: credit is inactive", id)
+	}
+	if len(allocations) == 0 {
+		return nil, fmt.Errorf("Unable to apply credit memo %s: must provide at least one invoice", id)
+	}
+	_, err = r.client.validateInvoiceAllocations(credit.CustomerID, credit.Remaining(), allocations)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to apply credit memo %s: %v", id, err)
+	}
+
+	var applied []map[string]interface{}
+	for _, a := range allocations {
+		applied = append(applied, map[string]interface{}{
+			"invoiceId": a.InvoiceID,
+			"amount":    a.Amount,
+		})
+	}
+	values := map[string]interface{}{
+		"creditMemoId": id,
+		"invoices":     applied,
+	}
+	err = r.client.mutate(applyCreditMemoEndpoint, values, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to apply credit memo %s: %v", id, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Applied credit memo %s to invoices %v", id, allocations))
+
+	var updated []Invoice
+	for _, a := range allocations {
+		inv, err := r.client.Invoice.Get(a.InvoiceID)
+		if err != nil {
+			return updated, fmt.Errorf("Applied credit memo %s, but unable to get updated invoice: %v", id, err)
+		}
+		updated = append(updated, inv)
+	}
+	return updated, nil
+}
@@ -5,22 +5,34 @@ import (
 	"encoding/csv"
 	"fmt"
 	"io/ioutil"
-	"strconv"
 )
 
 // CreateInvoicesFromCSV converts rows in a CSV into Bill.com invoices
 // File must match template in "csv_example.csv"
+// Invoices that already exist for the same customer and invoice number are skipped,
+// so it is safe to re-run a file after a partial failure.
 // Best practice is to run c.UpdateInvoiceMappings() prior so that all lookups succeed
 func (c *Client) CreateInvoicesFromCSV(path string) error {
+	_, err := c.UpsertInvoicesFromCSV(path, SkipExisting)
+	return err
+}
+
+// UpsertInvoicesFromCSV converts rows in a CSV into Bill.com invoices with Invoice.Upsert,
+// handling invoices that already exist according to policy.
+// Returns the invoices as stored by Bill.com with what was done to each, in file order, up to the first error.
+// To email only the invoices that this run created, pass NewlyCreated(results) to c.Invoice.SendBatch
+// File must match template in "csv_example.csv"
+// Best practice is to run c.UpdateInvoiceMappings() prior so that all lookups succeed
+func (c *Client) UpsertInvoicesFromCSV(path string, policy UpsertPolicy) ([]UpsertResult, error) {
 
 	data, err := ioutil.ReadFile(path)
 	if err != nil {
-		return fmt.Errorf("unable to read file at %s: %s", path, err)
+		return nil, fmt.Errorf("unable to read file at %s: %s", path, err)
 	}
 	reader := csv.NewReader(bytes.NewReader(data))
 	records, error := reader.ReadAll()
 	if error != nil {
-		return fmt.Errorf("error parsing CSV at %s: %s", path, err)
+		return nil, fmt.Errorf("error parsing CSV at %s: %s", path, err)
 	}
 	for i, record := range records {
 		item := record[5]
@@ -57,7 +69,7 @@ func (c *Client) CreateInvoicesFromCSV(path string) error {
 	// then linesInInvoice would = [2, 2, 1]
 	linesInInvoice := make([]int, len(invoiceStartLines))
 	if len(invoiceStartLines) == 0 {
-		return fmt.Errorf("no invoices to write")
+		return nil, fmt.Errorf("no invoices to write")
 	}
 	for idx := range invoiceStartLines {
 		if idx == len(invoiceStartLines)-1 {
@@ -68,6 +80,7 @@ func (c *Client) CreateInvoicesFromCSV(path string) error {
 		}
 
 	}
+	var invoices []UpsertResult
 	for idx, invoiceStartLine := range invoiceStartLines {
 		firstRow := records[invoiceStartLine]
 		customer := firstRow[0]
@@ -79,25 +92,27 @@ func (c *Client) CreateInvoicesFromCSV(path string) error {
 		for line := invoiceStartLine; line < invoiceStartLine+linesInInvoice[idx]; line++ {
 			row := records[line]
 			item := row[5]
-			amount, err := strconv.ParseFloat(row[6], 8)
-
+			amount, err := ParseMoney(row[6])
+			if err != nil {
+				return invoices, fmt.Errorf("error parsing amount on row %v: %v", line, err)
+			}
 			description := row[7]
-			li, err := NewInvoiceLineItem("custom", item, amount, description)
+			li, err := c.NewInvoiceLineItem("custom", item, amount, description)
 			if err != nil {
-				return fmt.Errorf("error creating invoice line item on row %v: %v", line, err)
+				return invoices, fmt.Errorf("error creating invoice line item on row %v: %v", line, err)
 			}
 			invoiceLineItems = append(invoiceLineItems, li)
 		}
-		invoice, err := NewInvoice("custom", customer, invoiceNumber, dueDate, class, location, invoiceLineItems)
+		invoice, err := c.NewInvoice("custom", customer, invoiceNumber, dueDate, class, location, invoiceLineItems)
 		if err != nil {
-			return fmt.Errorf("error creating invoice that starts on line %v: %v", invoiceStartLine, err)
+			return invoices, fmt.Errorf("error creating invoice that starts on line %v: %v", invoiceStartLine, err)
 		}
-		err = c.Invoice.Create(invoice)
+		result, err := c.Invoice.Upsert(invoice, policy)
 		if err != nil {
-			return fmt.Errorf("error sending invoice to Bill.com that starts on line %v: %v", invoiceStartLine, err)
+			return invoices, fmt.Errorf("error sending invoice to Bill.com that starts on line %v: %v", invoiceStartLine, err)
 		}
-
+		invoices = append(invoices, result)
 	}
 
-	return nil
+	return invoices, nil
 }
@@ -0,0 +1,123 @@
+package bdc
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type customerBankAccountResponse struct {
+	Data []CustomerBankAccount `json:"response_data"`
+}
+
+// CustomerBankAccount is a customer's bank account in Bill.com, eg for charging the customer by ACH
+type CustomerBankAccount struct {
+	Entity         string       `json:"entity"`
+	ID             string       `json:"id"`
+	CreatedTime    Timestamp    `json:"createdTime"`
+	UpdatedTime    Timestamp    `json:"updatedTime"`
+	IsActive       ActiveStatus `json:"isActive"`
+	CustomerID     string       `json:"customerId"`
+	NameOnAccount  string       `json:"nameOnAcct"`
+	RoutingNumber  string       `json:"routingNumber"`
+	AccountNumber  string       `json:"accountNumber"`
+	IsSavings      bool         `json:"isSavings"`
+	IsPersonalAcct bool         `json:"isPersonalAcct"`
+	AgreedWithTOS  bool         `json:"agreedWithTOS"`
+}
+
+type customerBankAccountResource struct {
+	resourceFields
+}
+
+// All customer bank accounts
+func (r customerBankAccountResource) All(parameters ...*Parameters) ([]CustomerBankAccount, error) {
+	results := r.client.getAll(r.suffix, parameters)
+
+	var retList []CustomerBankAccount
+	var errSlice []string
+	for _, resp := range results {
+		if resp.err != nil {
+			errSlice = append(errSlice, fmt.Sprintf("Error on page %v: %v", resp.page, resp.err))
+		} else {
+			var goodResp customerBankAccountResponse
+			if err := json.Unmarshal(resp.result, &goodResp); err != nil {
+				errSlice = append(errSlice, fmt.Sprintf("Unable to read page %v: %v", resp.page, err))
+				continue
+			}
+			retList = append(retList, goodResp.Data...)
+		}
+	}
+	err := handleErrSlice(errSlice)
+
+	return retList, err
+}
+
+// ForCustomer returns the active customer bank accounts linked to a customer
+func (r customerBankAccountResource) ForCustomer(customerID string) ([]CustomerBankAccount, error) {
+	p := NewParameters()
+	p.AddFilter("isActive", "=", Active)
+	p.AddFilter("customerId", "=", customerID)
+	results, err := r.All(p)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get customer bank accounts for customer %s: %v", customerID, err)
+	}
+	return results, nil
+}
+
+// Create a customer bank account, linked to a customer by CustomerID.
+// Requires multi-factor authentication.
+// Returns the new customer bank account as stored by Bill.com
+func (r customerBankAccountResource) Create(entity CustomerBankAccount) (CustomerBankAccount, error) {
+	if err := r.client.requireMFA(); err != nil {
+		return CustomerBankAccount{}, fmt.Errorf("Unable to create customer bank account: %v", err)
+	}
+	if entity.CustomerID == "" {
+		return CustomerBankAccount{}, fmt.Errorf("Unable to create customer bank account: must provide customer ID")
+	}
+	entity.Entity = "CustomerBankAccount"
+	var created CustomerBankAccount
+	err := r.client.createEntity(r.suffix, entity, &created)
+	if err != nil {
+		if unreadable, ok := err.(*UnreadableResultError); ok {
+			return CustomerBankAccount{}, unreadable
+		}
+		return CustomerBankAccount{}, fmt.Errorf("Unable to create customer bank account for customer %s: %v", entity.CustomerID, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Created customer bank account %s for customer %s", created.ID, created.CustomerID))
+	return created, nil
+}
+
+// Update one customer bank account.
+// Requires multi-factor authentication.
+// Supply the customer bank account ID and only the fields to change, eg bdc.Set("nameOnAcct", "Jane Doe");
+// all other fields will be preserved. Zero and empty values are applied as is.
+// To guard against overwriting someone else's changes, include bdc.IfUnchanged(base).
+// Returns the updated customer bank account as stored by Bill.com
+func (r customerBankAccountResource) Update(id string, fields ...Field) (CustomerBankAccount, error) {
+	if err := r.client.requireMFA(); err != nil {
+		return CustomerBankAccount{}, fmt.Errorf("Unable to update customer bank account %v: %v", id, err)
+	}
+	var updated CustomerBankAccount
+	changes, err := r.client.patchEntity(r.suffix, id, fields, &updated)
+	if err != nil {
+		if conflict, ok := err.(*ConflictError); ok {
+			return CustomerBankAccount{}, conflict
+		}
+		if unreadable, ok := err.(*UnreadableResultError); ok {
+			return CustomerBankAccount{}, unreadable
+		}
+		return CustomerBankAccount{}, fmt.Errorf("Unable to update customer bank account %v: %v", id, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Updated customer bank account %s: %s", updated.ID, changes))
+	return updated, nil
+}
+
+// Deactivate one customer bank account
+func (r customerBankAccountResource) Deactivate(id string) error {
+	err := r.client.deactivateEntity(r.suffix, id)
+	if err != nil {
+		return fmt.Errorf("Unable to deactivate customer bank account %v: %v", id, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Deactivated customer bank account %s", id))
+	return nil
+}
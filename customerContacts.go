@@ -0,0 +1,114 @@
+package bdc
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type customerContactResponse struct {
+	Data []CustomerContact `json:"response_data"`
+}
+
+// CustomerContact is a person at a customer in Bill.com, eg a billing contact
+type CustomerContact struct {
+	Entity      string       `json:"entity"`
+	ID          string       `json:"id"`
+	CreatedTime Timestamp    `json:"createdTime"`
+	UpdatedTime Timestamp    `json:"updatedTime"`
+	IsActive    ActiveStatus `json:"isActive"`
+	CustomerID  string       `json:"customerId"`
+	FirstName   string       `json:"firstName"`
+	LastName    string       `json:"lastName"`
+	Email       string       `json:"email"`
+	Phone       string       `json:"phone"`
+	Mobile      string       `json:"mobile"`
+}
+
+type customerContactResource struct {
+	resourceFields
+}
+
+// All customer contacts
+func (r customerContactResource) All(parameters ...*Parameters) ([]CustomerContact, error) {
+	results := r.client.getAll(r.suffix, parameters)
+
+	var retList []CustomerContact
+	var errSlice []string
+	for _, resp := range results {
+		if resp.err != nil {
+			errSlice = append(errSlice, fmt.Sprintf("Error on page %v: %v", resp.page, resp.err))
+		} else {
+			var goodResp customerContactResponse
+			if err := json.Unmarshal(resp.result, &goodResp); err != nil {
+				errSlice = append(errSlice, fmt.Sprintf("Unable to read page %v: %v", resp.page, err))
+				continue
+			}
+			retList = append(retList, goodResp.Data...)
+		}
+	}
+	err := handleErrSlice(errSlice)
+
+	return retList, err
+}
+
+// ForCustomer returns the active customer contacts linked to a customer
+func (r customerContactResource) ForCustomer(customerID string) ([]CustomerContact, error) {
+	p := NewParameters()
+	p.AddFilter("isActive", "=", Active)
+	p.AddFilter("customerId", "=", customerID)
+	results, err := r.All(p)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get customer contacts for customer %s: %v", customerID, err)
+	}
+	return results, nil
+}
+
+// Create a customer contact, linked to a customer by CustomerID.
+// Returns the new customer contact as stored by Bill.com
+func (r customerContactResource) Create(entity CustomerContact) (CustomerContact, error) {
+	if entity.CustomerID == "" {
+		return CustomerContact{}, fmt.Errorf("Unable to create customer contact: must provide customer ID")
+	}
+	entity.Entity = "CustomerContact"
+	var created CustomerContact
+	err := r.client.createEntity(r.suffix, entity, &created)
+	if err != nil {
+		if unreadable, ok := err.(*UnreadableResultError); ok {
+			return CustomerContact{}, unreadable
+		}
+		return CustomerContact{}, fmt.Errorf("Unable to create customer contact for customer %s: %v", entity.CustomerID, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Created customer contact %s for customer %s", created.ID, created.CustomerID))
+	return created, nil
+}
+
+// Update one customer contact.
+// Supply the customer contact ID and only the fields to change, eg bdc.Set("email", "");
+// all other fields will be preserved. Zero and empty values are applied as is.
+// To guard against overwriting someone else's changes, include bdc.IfUnchanged(base).
+// Returns the updated customer contact as stored by Bill.com
+func (r customerContactResource) Update(id string, fields ...Field) (CustomerContact, error) {
+	var updated CustomerContact
+	changes, err := r.client.patchEntity(r.suffix, id, fields, &updated)
+	if err != nil {
+		if conflict, ok := err.(*ConflictError); ok {
+			return CustomerContact{}, conflict
+		}
+		if unreadable, ok := err.(*UnreadableResultError); ok {
+			return CustomerContact{}, unreadable
+		}
+		return CustomerContact{}, fmt.Errorf("Unable to update customer contact %v: %v", id, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Updated customer contact %s: %s", updated.ID, changes))
+	return updated, nil
+}
+
+// Deactivate one customer contact
+func (r customerContactResource) Deactivate(id string) error {
+	err := r.client.deactivateEntity(r.suffix, id)
+	if err != nil {
+		return fmt.Errorf("Unable to deactivate customer contact %v: %v", id, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Deactivated customer contact %s", id))
+	return nil
+}
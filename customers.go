@@ -3,6 +3,7 @@ package bdc
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -16,14 +17,14 @@ type customerResp struct {
 
 // Customer in Bill.com
 type Customer struct {
-	ID            string `json:"id"`
-	CreatedTime   string `json:"createdTime"`
-	UpdatedTime   string `json:"updatedTime"`
-	Entity        string `json:"entity"`
-	IsActive      string `json:"isActive"`
-	Name          string `json:"name"`
-	AccountNumber string `json:"accNumber"`
-	Email         string `json:"email"`
+	ID            string       `json:"id"`
+	CreatedTime   Timestamp    `json:"createdTime"`
+	UpdatedTime   Timestamp    `json:"updatedTime"`
+	Entity        string       `json:"entity"`
+	IsActive      ActiveStatus `json:"isActive"`
+	Name          string       `json:"name"`
+	AccountNumber string       `json:"accNumber"`
+	Email         string       `json:"email"`
 }
 
 type customerResource struct {
@@ -37,7 +38,9 @@ func (r customerResource) Get(id string) (Customer, error) {
 		return Customer{}, fmt.Errorf("Unable to get customer id %v: %v", id, err)
 	}
 	var goodResp customerResp
-	json.Unmarshal(cust, &goodResp)
+	if err := json.Unmarshal(cust, &goodResp); err != nil {
+		return Customer{}, fmt.Errorf("Unable to read customer id %v: %v", id, err)
+	}
 	return goodResp.Data, nil
 }
 
@@ -52,7 +55,10 @@ func (r customerResource) All(parameters ...*Parameters) ([]Customer, error) {
 			errSlice = append(errSlice, fmt.Sprintf("Error on page %v: %v", resp.page, resp.err))
 		} else {
 			var goodResp customerResponse
-			json.Unmarshal(resp.result, &goodResp)
+			if err := json.Unmarshal(resp.result, &goodResp); err != nil {
+				errSlice = append(errSlice, fmt.Sprintf("Unable to read page %v: %v", resp.page, err))
+				continue
+			}
 			retList = append(retList, goodResp.Data...)
 		}
 	}
@@ -74,3 +80,124 @@ func (r customerResource) Since(t time.Time, p *Parameters) ([]Customer, error)
 	}
 	return customers, nil
 }
+
+// Update one customer.
+// Supply the customer ID and only the fields to change, eg bdc.Set("email", "");
+// all other fields will be preserved. Zero and empty values are applied as is.
+// To guard against overwriting someone else's changes, include bdc.IfUnchanged(base).
+// Returns the updated customer as stored by Bill.com
+func (r customerResource) Update(id string, fields ...Field) (Customer, error) {
+	var updated Customer
+	changes, err := r.client.patchEntity(r.suffix, id, fields, &updated)
+	if err != nil {
+		if conflict, ok := err.(*ConflictError); ok {
+			return Customer{}, conflict
+		}
+		if unreadable, ok := err.(*UnreadableResultError); ok {
+			return Customer{}, unreadable
+		}
+		return Customer{}, fmt.Errorf("Unable to update customer %v: %v", id, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Updated customer %s: %s", updated.ID, changes))
+	return updated, nil
+}
+
+// Contacts returns everyone who should receive a customer's invoices:
+// the customer's own email, if any, followed by each active contact with an email address.
+// The customer's own email is returned as a CustomerContact with no ID
+func (r customerResource) Contacts(id string) ([]CustomerContact, error) {
+	cust, err := r.Get(id)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get contacts for customer %v: %v", id, err)
+	}
+	contacts, err := r.client.CustomerContact.ForCustomer(id)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get contacts for customer %v: %v", id, err)
+	}
+	var recipients []CustomerContact
+	seen := make(map[string]bool)
+	if cust.Email != "" {
+		recipients = append(recipients, CustomerContact{Entity: "CustomerContact", CustomerID: id, Email: cust.Email})
+		seen[strings.ToLower(cust.Email)] = true
+	}
+	for _, contact := range contacts {
+		email := strings.ToLower(contact.Email)
+		if email == "" || seen[email] {
+			continue
+		}
+		seen[email] = true
+		recipients = append(recipients, contact)
+	}
+	return recipients, nil
+}
+
+const chargeCustomerEndpoint = "ChargeCustomer.json"
+
+// Charge debits a customer's bank account by ACH and applies the payment to the customer's open invoices.
+// The customer must have authorized auto-debit from an active bank account linked to it (see CustomerBankAccount).
+// Requires multi-factor authentication.
+// Each allocation may not exceed the amount due on its invoice, and all allocations together must equal amount,
+// so that no more is debited than is applied to invoices. The payment is dated today in UTC.
+// Returns the payment as stored by Bill.com
+func (r customerResource) Charge(id, bankAccountID string, amount Money, invoices ...InvoiceAllocation) (PaymentReceived, error) {
+	if amount <= 0 {
+		return PaymentReceived{}, fmt.Errorf("Unable to charge customer %s: amount must be greater than 0", id)
+	}
+	accounts, err := r.client.CustomerBankAccount.ForCustomer(id)
+	if err != nil {
+		return PaymentReceived{}, fmt.Errorf("Unable to charge customer %s: %v", id, err)
+	}
+	var found bool
+	for _, account := range accounts {
+		if account.ID == bankAccountID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return PaymentReceived{}, fmt.Errorf("Unable to charge customer %s: %s is not an active bank account of the customer", id, bankAccountID)
+	}
+	var total Money
+	for _, a := range invoices {
+		total += a.Amount
+	}
+	if total != amount {
+		return PaymentReceived{}, fmt.Errorf("Unable to charge customer %s: allocations total %v, which does not equal the %v to be charged", id, total, amount)
+	}
+	_, err = r.client.validateInvoiceAllocations(id, amount, invoices)
+	if err != nil {
+		return PaymentReceived{}, fmt.Errorf("Unable to charge customer %s: %v", id, err)
+	}
+
+	var invoicePays []map[string]interface{}
+	for _, a := range invoices {
+		invoicePays = append(invoicePays, map[string]interface{}{
+			"entity":    "InvoicePay",
+			"invoiceId": a.InvoiceID,
+			"amount":    a.Amount,
+		})
+	}
+	values := map[string]interface{}{
+		"obj": map[string]interface{}{
+			"entity":                "ReceivedPay",
+			"customerId":            id,
+			"customerBankAccountId": bankAccountID,
+			"paymentDate":           time.Now().UTC().Format(DateFormat),
+			"paymentType":           PaymentACH,
+			"amount":                amount,
+			"invoicePays":           invoicePays,
+		},
+	}
+	// authenticate only once the charge is known to be valid, so that a bad request never prompts for a token
+	if err := r.client.requireMFA(); err != nil {
+		return PaymentReceived{}, fmt.Errorf("Unable to charge customer %s: %v", id, err)
+	}
+	var payment PaymentReceived
+	err = r.client.mutate(chargeCustomerEndpoint, values, &payment)
+	if err != nil {
+		return PaymentReceived{}, fmt.Errorf("Unable to charge customer %s in amount %v: %v", id, amount, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Charged customer %s in amount %v from bank account %s applied to %v (payment %s)",
+		id, amount, bankAccountID, invoices, payment.ID))
+	return payment, nil
+}
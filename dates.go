@@ -0,0 +1,125 @@
+package bdc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Date is a calendar date in Bill.com, eg an invoice's due date.
+// It is encoded to and decoded from JSON as a string formatted according to DateFormat.
+// JSON null decodes to the zero Date, and the zero Date encodes to null
+type Date struct {
+	time.Time
+}
+
+// Timestamp is a point in time in Bill.com, eg an entity's updated time.
+// It is encoded to and decoded from JSON as a string formatted according to TimeFormat.
+// JSON null decodes to the zero Timestamp, and the zero Timestamp encodes to null
+type Timestamp struct {
+	time.Time
+}
+
+// NewDate returns the Date for a year, month and day
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// ParseDate parses a date formatted according to DateFormat, ie YYYY-MM-DD
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(DateFormat, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("Unable to parse %q as date in format %s: %v", s, DateFormat, err)
+	}
+	return Date{t}, nil
+}
+
+// ParseTimestamp parses a time formatted according to TimeFormat
+func ParseTimestamp(s string) (Timestamp, error) {
+	t, err := time.Parse(TimeFormat, s)
+	if err != nil {
+		return Timestamp{}, fmt.Errorf("Unable to parse %q as time in format %s: %v", s, TimeFormat, err)
+	}
+	return Timestamp{t}, nil
+}
+
+// AddDate returns the Date years, months and days after d
+func (d Date) AddDate(years, months, days int) Date {
+	return Date{d.Time.AddDate(years, months, days)}
+}
+
+// String formats d according to DateFormat, or returns an empty string for the zero Date
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format(DateFormat)
+}
+
+// Bill.com always sends milliseconds, even when zero; TimeFormat (used for parsing) drops them
+const timestampLayout = "2006-01-02T15:04:05.000-0700"
+
+// String formats t as Bill.com does, according to TimeFormat but always with milliseconds,
+// or returns an empty string for the zero Timestamp
+func (t Timestamp) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(timestampLayout)
+}
+
+// MarshalJSON encodes d as a DateFormat string, or null if d is zero
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON decodes a DateFormat string; null or an empty string decodes to the zero Date
+func (d *Date) UnmarshalJSON(b []byte) error {
+	s, ok := unquote(b)
+	if !ok {
+		*d = Date{}
+		return nil
+	}
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalJSON encodes t as a TimeFormat string, or null if t is zero
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + t.String() + `"`), nil
+}
+
+// UnmarshalJSON decodes a TimeFormat string; null or an empty string decodes to the zero Timestamp
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	s, ok := unquote(b)
+	if !ok {
+		*t = Timestamp{}
+		return nil
+	}
+	parsed, err := ParseTimestamp(s)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// unquote returns the contents of a JSON string, or false if the value is null or empty
+func unquote(b []byte) (string, bool) {
+	s := string(b)
+	if s == "null" {
+		return "", false
+	}
+	s = strings.Trim(s, `"`)
+	return s, s != ""
+}
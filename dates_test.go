@@ -0,0 +1,107 @@
+package bdc
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDateJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Date
+		wantErr bool
+	}{
+		{`"2019-04-26"`, NewDate(2019, time.April, 26), false},
+		{`null`, Date{}, false},
+		{`""`, Date{}, false},
+		{`"2019-4-26"`, Date{}, true},
+		{`"04/26/2019"`, Date{}, true},
+		{`"2019-02-30"`, Date{}, true},
+		{`"2019-04-26T00:00:00.000-0700"`, Date{}, true},
+	}
+	for _, tt := range tests {
+		var got Date
+		err := json.Unmarshal([]byte(tt.in), &got)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Unmarshal(%s) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !got.Equal(tt.want.Time) {
+			t.Errorf("Unmarshal(%s) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	roundTrips := []struct {
+		d    Date
+		json string
+	}{
+		{NewDate(2019, time.April, 26), `"2019-04-26"`},
+		{NewDate(2020, time.February, 29), `"2020-02-29"`},
+		{Date{}, `null`},
+	}
+	for _, tt := range roundTrips {
+		b, err := json.Marshal(tt.d)
+		if err != nil {
+			t.Fatalf("Marshal(%v): %v", tt.d, err)
+		}
+		if string(b) != tt.json {
+			t.Errorf("Marshal(%v) = %s, want %s", tt.d, b, tt.json)
+		}
+		var back Date
+		if err := json.Unmarshal(b, &back); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", b, err)
+		}
+		if !back.Equal(tt.d.Time) {
+			t.Errorf("Unmarshal(%s) = %v, want %v", b, back, tt.d)
+		}
+	}
+}
+
+func TestTimestampJSON(t *testing.T) {
+	pdt := time.FixedZone("", -7*60*60)
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{`"2019-04-26T15:04:05.000-0700"`, time.Date(2019, time.April, 26, 15, 4, 5, 0, pdt), false},
+		{`"2019-04-26T15:04:05.123-0700"`, time.Date(2019, time.April, 26, 15, 4, 5, 123000000, pdt), false},
+		{`"2019-04-26T15:04:05-0700"`, time.Date(2019, time.April, 26, 15, 4, 5, 0, pdt), false},
+		{`null`, time.Time{}, false},
+		{`""`, time.Time{}, false},
+		{`"2019-04-26"`, time.Time{}, true},
+		{`"2019-04-26T15:04:05Z"`, time.Time{}, true},
+		{`"yesterday"`, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		var got Timestamp
+		err := json.Unmarshal([]byte(tt.in), &got)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Unmarshal(%s) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("Unmarshal(%s) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	roundTrips := []string{
+		`"2019-04-26T15:04:05.000-0700"`, // Bill.com always sends milliseconds
+		`"2019-04-26T15:04:05.120+0000"`,
+		`null`,
+	}
+	for _, in := range roundTrips {
+		var ts Timestamp
+		if err := json.Unmarshal([]byte(in), &ts); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", in, err)
+		}
+		b, err := json.Marshal(ts)
+		if err != nil {
+			t.Fatalf("Marshal(%v): %v", ts, err)
+		}
+		if string(b) != in {
+			t.Errorf("round trip of %s = %s", in, b)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package bdc
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type departmentResponse struct {
+	Data []Department `json:"response_data"`
+}
+
+type departmentResp struct {
+	Data Department `json:"response_data"`
+}
+
+// Department is an accounting department in Bill.com (matches QBO department)
+type Department struct {
+	Entity      string       `json:"entity"`
+	CreatedTime Timestamp    `json:"createdTime"`
+	UpdatedTime Timestamp    `json:"updatedTime"`
+	IsActive    ActiveStatus `json:"isActive"`
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	ShortName   string       `json:"shortName"`
+	Description string       `json:"description"`
+	ParentID    string       `json:"parentDepartmentId"`
+}
+
+type departmentResource struct {
+	resourceFields
+}
+
+// Get returns a single Department object
+func (r departmentResource) Get(id string) (Department, error) {
+	b, err := r.client.getOne(r.suffix, id)
+	if err != nil {
+		return Department{}, fmt.Errorf("Unable to get department id %v: %v", id, err)
+	}
+	var goodResp departmentResp
+	if err := json.Unmarshal(b, &goodResp); err != nil {
+		return Department{}, fmt.Errorf("Unable to read department id %v: %v", id, err)
+	}
+	return goodResp.Data, nil
+}
+
+// All departments
+func (r departmentResource) All(parameters ...*Parameters) ([]Department, error) {
+	results := r.client.getAll(r.suffix, parameters)
+
+	var retList []Department
+	var errSlice []string
+	for _, resp := range results {
+		if resp.err != nil {
+			errSlice = append(errSlice, fmt.Sprintf("Error on page %v: %v", resp.page, resp.err))
+		} else {
+			var goodResp departmentResponse
+			if err := json.Unmarshal(resp.result, &goodResp); err != nil {
+				errSlice = append(errSlice, fmt.Sprintf("Unable to read page %v: %v", resp.page, err))
+				continue
+			}
+			retList = append(retList, goodResp.Data...)
+		}
+	}
+	err := handleErrSlice(errSlice)
+
+	return retList, err
+}
+
+// Since returns all departments updated since the time provided.
+// If no additional params to provide, must pass nil explicitly
+func (r departmentResource) Since(t time.Time, p *Parameters) ([]Department, error) {
+	if p == nil {
+		p = NewParameters()
+	}
+	p.AddFilter("updatedTime", ">", t.Format(TimeFormat))
+	results, err := r.client.Department.All(p)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get all departments updated since %s: %v", t, err)
+	}
+	return results, nil
+}
@@ -0,0 +1,114 @@
+package bdc
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	disbursementDataEndpoint = "GetDisbursementData.json"
+	checkImageDataEndpoint   = "GetCheckImageData.json"
+)
+
+// Disbursement describes how and when the funds of a payment made were sent to the vendor
+type Disbursement struct {
+	Status         string `json:"disbursementStatus"`
+	PaymentType    string `json:"paymentType"` // eg "Check", "ACH"
+	CheckNumber    string `json:"checkNumber"`
+	TrackingNumber string `json:"trackingNumber"`
+	ProcessDate    Date   `json:"processDate"`
+	SentDate       Date   `json:"sentDate"`
+	ExpectedDate   Date   `json:"expectedArrivalDate"`
+	ClearedDate    Date   `json:"clearedDate"`
+	VoidedDate     Date   `json:"voidDate"`
+}
+
+// CheckImage is the image of a cleared check, as scanned by the bank
+type CheckImage struct {
+	CheckNumber   string `json:"checkNumber"`
+	Amount        Money  `json:"amount"`
+	ClearedDate   Date   `json:"clearedDate"`
+	FrontImageURL string `json:"frontImageUrl"`
+	BackImageURL  string `json:"backImageUrl"`
+}
+
+// PaymentEvent is a single step in the life of a payment made, eg "Sent" or "Cleared"
+type PaymentEvent struct {
+	Time   time.Time
+	Event  string
+	Detail string
+}
+
+// Disbursement returns the disbursement status of a payment made, including its check number for check payments
+func (r paymentMadeResource) Disbursement(id string) (Disbursement, error) {
+	var d Disbursement
+	err := r.client.call(disbursementDataEndpoint, map[string]interface{}{"sentPayId": id}, &d)
+	if err != nil {
+		return Disbursement{}, fmt.Errorf("Unable to get disbursement data for payment %s: %v", id, err)
+	}
+	return d, nil
+}
+
+// CheckImage returns the image of the check that paid a payment made. Only available once the check has cleared
+func (r paymentMadeResource) CheckImage(id string) (CheckImage, error) {
+	var img CheckImage
+	err := r.client.call(checkImageDataEndpoint, map[string]interface{}{"sentPayId": id}, &img)
+	if err != nil {
+		return CheckImage{}, fmt.Errorf("Unable to get check image data for payment %s: %v", id, err)
+	}
+	return img, nil
+}
+
+// Track returns the timeline of a payment made, oldest first:
+// when it was created, scheduled, sent, expected to arrive, cleared or voided, as far as each is known.
+// Useful for answering a vendor who says they were not paid
+func (r paymentMadeResource) Track(id string) ([]PaymentEvent, error) {
+	payment, err := r.Get(id)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to track payment %s: %v", id, err)
+	}
+	d, err := r.Disbursement(id)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to track payment %s: %v", id, err)
+	}
+
+	var events []PaymentEvent
+	add := func(t time.Time, event, detail string) {
+		if t.IsZero() {
+			return
+		}
+		events = append(events, PaymentEvent{Time: t, Event: event, Detail: detail})
+	}
+	add(payment.CreatedTime.Time, "Created", fmt.Sprintf("%v for bill %s", payment.Amount, payment.BillID))
+	add(d.ProcessDate.Time, "Scheduled", fmt.Sprintf("status %s", payment.PaymentStatus))
+	sent := d.PaymentType
+	if d.CheckNumber != "" {
+		sent = fmt.Sprintf("%s number %s", d.PaymentType, d.CheckNumber)
+	}
+	if d.TrackingNumber != "" {
+		sent = fmt.Sprintf("%s, tracking number %s", sent, d.TrackingNumber)
+	}
+	add(d.SentDate.Time, "Sent", sent)
+	add(d.ExpectedDate.Time, "Expected", "expected arrival")
+	add(d.VoidedDate.Time, "Voided", "")
+
+	cleared := d.ClearedDate
+	var clearedDetail string
+	if d.CheckNumber != "" && !cleared.IsZero() {
+		img, err := r.CheckImage(id)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to track payment %s: %v", id, err)
+		}
+		if !img.ClearedDate.IsZero() {
+			cleared = img.ClearedDate
+		}
+		clearedDetail = fmt.Sprintf("check %s cleared for %v; image %s", img.CheckNumber, img.Amount, img.FrontImageURL)
+	}
+	add(cleared.Time, "Cleared", clearedDetail)
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+	return events, nil
+}
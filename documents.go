@@ -0,0 +1,169 @@
+package bdc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+)
+
+const (
+	uploadAttachmentEndpoint = "UploadAttachment.json"
+	getDocumentsEndpoint     = "GetDocuments.json"
+	getDocumentPagesEndpoint = "GetDocumentPages.json"
+)
+
+// Document attached to an entity (eg an invoice or bill) in Bill.com
+type Document struct {
+	Entity      string    `json:"entity"`
+	ID          string    `json:"id"`
+	FileName    string    `json:"fileName"`
+	CreatedTime Timestamp `json:"createdTime"`
+	UpdatedTime Timestamp `json:"updatedTime"`
+}
+
+// documentPages locates the pages of a document for download
+type documentPages struct {
+	ID       string `json:"id"`
+	NumPages int    `json:"numPages"`
+	FileURL  string `json:"fileUrl"`
+}
+
+// documents attached to any entity
+type documentResource struct {
+	client *Client
+}
+
+// Upload attaches a file to the entity with the ID provided (eg an invoice or bill)
+func (r documentResource) Upload(entityID, fileName string, file io.Reader) error {
+	if plan := r.client.dryRunPlan(); plan != nil {
+		values := map[string]interface{}{"id": entityID, "fileName": fileName}
+		return r.client.planCall(plan, uploadAttachmentEndpoint, values, nil)
+	}
+	body, contentType, err := encodeAttachmentData(r.client, entityID, fileName, file)
+	if err != nil {
+		return fmt.Errorf("Unable to upload %s to %s: %v", fileName, entityID, err)
+	}
+	_, err = r.client.sendWithType(uploadAttachmentEndpoint, contentType, body)
+	if err != nil {
+		return fmt.Errorf("Unable to upload %s to %s: %v", fileName, entityID, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Uploaded %s to %s", fileName, entityID))
+	return nil
+}
+
+// convert an attachment into a multipart form body
+func encodeAttachmentData(c *Client, entityID, fileName string, file io.Reader) (io.Reader, string, error) {
+	jsonValues, err := json.Marshal(map[string]interface{}{"id": entityID, "fileName": fileName})
+	if err != nil {
+		return nil, "", fmt.Errorf("Unable to encode data: %v", err)
+	}
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	w.WriteField("data", string(jsonValues))
+	for key, values := range c.sessionValues() {
+		w.WriteField(key, values[0])
+	}
+	part, err := w.CreateFormFile("file", fileName)
+	if err != nil {
+		return nil, "", fmt.Errorf("Unable to encode file: %v", err)
+	}
+	_, err = io.Copy(part, file)
+	if err != nil {
+		return nil, "", fmt.Errorf("Unable to read file: %v", err)
+	}
+	err = w.Close()
+	if err != nil {
+		return nil, "", fmt.Errorf("Unable to encode file: %v", err)
+	}
+	return &body, w.FormDataContentType(), nil
+}
+
+// List returns the documents attached to the entity with the ID provided
+func (r documentResource) List(entityID string) ([]Document, error) {
+	var docs []Document
+	for start := 0; ; start += pageMax {
+		var page []Document
+		values := map[string]interface{}{"id": entityID, "start": start, "max": pageMax}
+		err := r.client.call(getDocumentsEndpoint, values, &page)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to list documents for %s: %v", entityID, err)
+		}
+		docs = append(docs, page...)
+		if len(page) < pageMax {
+			return docs, nil
+		}
+	}
+}
+
+// Download writes every page of a document to w, in order.
+// Returns an error without writing anything unless exactly one document matches documentID
+func (r documentResource) Download(documentID string, w io.Writer) error {
+	var raw json.RawMessage
+	err := r.client.call(getDocumentPagesEndpoint, map[string]interface{}{"id": documentID}, &raw)
+	if err != nil {
+		return fmt.Errorf("Unable to get pages of document %s: %v", documentID, err)
+	}
+	var found []documentPages
+	if bytes.HasPrefix(bytes.TrimSpace(raw), []byte("[")) {
+		err = json.Unmarshal(raw, &found)
+	} else {
+		found = make([]documentPages, 1)
+		err = json.Unmarshal(raw, &found[0])
+	}
+	if err != nil {
+		return fmt.Errorf("Unable to read pages of document %s: %v", documentID, err)
+	}
+	var matches []documentPages
+	for _, doc := range found {
+		// a lone document without an ID is the one requested
+		if doc.ID == documentID || (doc.ID == "" && len(found) == 1) {
+			matches = append(matches, doc)
+		}
+	}
+	if len(matches) != 1 {
+		return fmt.Errorf("Unable to download document %s: %d documents matched, expected exactly 1", documentID, len(matches))
+	}
+	pages := matches[0]
+	for page := 1; page <= pages.NumPages; page++ {
+		err := r.downloadPage(pages.FileURL, page, w)
+		if err != nil {
+			return fmt.Errorf("Unable to download page %d of document %s: %v", page, documentID, err)
+		}
+	}
+	return nil
+}
+
+func (r documentResource) downloadPage(fileURL string, page int, w io.Writer) error {
+	u, err := url.Parse(fileURL)
+	if err != nil {
+		return fmt.Errorf("Unable to parse file URL %s: %v", fileURL, err)
+	}
+	base, _ := url.Parse(baseURL)
+	u = base.ResolveReference(u) // file URLs may be relative to the API
+	query := u.Query()
+	query.Set("sessionId", r.client.sessionID)
+	query.Set("pageNumber", fmt.Sprint(page))
+	u.RawQuery = query.Encode()
+
+	if err := r.client.begin(); err != nil {
+		return err
+	}
+	defer r.client.inflight.Done()
+	resp, err := http.Get(u.String())
+	if err != nil {
+		return fmt.Errorf("Unable to send Get request to %s: %v", fileURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Unable to get %s: %s", fileURL, resp.Status)
+	}
+	_, err = io.Copy(w, resp.Body)
+	if err != nil {
+		return fmt.Errorf("Unable to read resp body from %s: %v", fileURL, err)
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package bdc
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// A Plan records every change that a client in dry-run mode would have made to Bill.com.
+// Print it for a human-readable report
+type Plan struct {
+	mu    sync.Mutex
+	Steps []PlanStep
+	Notes []string // history messages that would have been written
+}
+
+// PlanStep is a single request that would have been sent to Bill.com
+type PlanStep struct {
+	Action   string                 // eg "Create", "Update"
+	Endpoint string                 // eg "Crud/Update/Invoice.json"
+	ID       string                 // entity ID; synthetic for creates
+	Payload  map[string]interface{} // data that would have been sent
+	Diffs    []FieldDiff            // changes against the current version, for updates
+}
+
+// FieldDiff is the change to a single top-level field that an update would have made
+type FieldDiff struct {
+	Field string
+	Old   interface{}
+	New   interface{}
+}
+
+// DryRun puts the client in dry-run mode and returns the Plan in which mutations are recorded.
+// In dry-run mode, reads are still sent to Bill.com, but creates, updates and other writes are not:
+// their payloads (and, for updates, their diffs against the current version) are recorded in the plan,
+// and creates return entities with synthetic IDs. History is recorded in the plan instead of the history file.
+// Dry-run mode lasts until c.EndDryRun() is called
+func (c *Client) DryRun() *Plan {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.plan == nil {
+		c.plan = new(Plan)
+	}
+	return c.plan
+}
+
+// EndDryRun returns the client to live mode and returns the Plan recorded while in dry-run mode, if any.
+// Calls already in progress finish in the mode they started in
+func (c *Client) EndDryRun() *Plan {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	plan := c.plan
+	c.plan = nil
+	return plan
+}
+
+// dryRunPlan returns the client's plan if it is in dry-run mode, or else nil
+func (c *Client) dryRunPlan() *Plan {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.plan
+}
+
+// record a step and return a synthetic ID for creates
+func (p *Plan) addStep(step PlanStep) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if step.ID == "" {
+		step.ID = fmt.Sprintf("dryrun-%d", len(p.Steps)+1)
+	}
+	p.Steps = append(p.Steps, step)
+	return step.ID
+}
+
+func (p *Plan) addNote(msg string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Notes = append(p.Notes, msg)
+}
+
+// String returns a human-readable report of the plan, with bank account and routing numbers masked
+func (p *Plan) String() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var b strings.Builder
+	fmt.Fprintf(&b, "Dry run: %d change(s) planned\n", len(p.Steps))
+	for i, step := range p.Steps {
+		fmt.Fprintf(&b, "%d. %s %s (%s)\n", i+1, step.Action, step.ID, step.Endpoint)
+		if step.Diffs != nil {
+			if len(step.Diffs) == 0 {
+				b.WriteString("     no changes\n")
+			}
+			for _, d := range step.Diffs {
+				before, after := maskSensitive(d.Old), maskSensitive(d.New)
+				if sensitiveFields[d.Field] {
+					before, after = maskValue(d.Old), maskValue(d.New)
+				}
+				fmt.Fprintf(&b, "     %s: %s -> %s\n", d.Field, formatValue(before), formatValue(after))
+			}
+			continue
+		}
+		fmt.Fprintf(&b, "     %s\n", formatValue(maskSensitive(step.Payload)))
+	}
+	if len(p.Notes) > 0 {
+		b.WriteString("History:\n")
+		for _, note := range p.Notes {
+			fmt.Fprintf(&b, "     %s\n", note)
+		}
+	}
+	return b.String()
+}
+
+func formatValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+// diffFields compares the top-level fields of the current and proposed versions of an entity
+func diffFields(current, proposed map[string]interface{}) []FieldDiff {
+	diffs := []FieldDiff{}
+	for field, newValue := range proposed {
+		if readOnlyFields[field] {
+			continue
+		}
+		oldValue := current[field]
+		if !reflect.DeepEqual(oldValue, newValue) {
+			diffs = append(diffs, FieldDiff{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Field < diffs[j].Field
+	})
+	return diffs
+}
+
+// planEntity records a create or update in plan instead of sending it,
+// and decodes the would-be result into result
+func (c *Client) planEntity(plan *Plan, action string, suffix string, entity interface{}, result interface{}) error {
+	if c.isClosed() {
+		return ErrClientClosed
+	}
+	cleaned, err := removeTimestamps(entity)
+	if err != nil {
+		return fmt.Errorf("Unable to plan entity: %v", err)
+	}
+	payload := cleaned.(map[string]interface{})
+	step := PlanStep{Action: action, Endpoint: "Crud/" + action + "/" + suffix, Payload: payload}
+
+	if action == "Update" {
+		id, _ := payload["id"].(string)
+		r, err := c.getOne(suffix, id)
+		if err != nil {
+			return fmt.Errorf("Unable to get current version of %v to plan update: %v", id, err)
+		}
+		var current confirmationResponse
+		json.Unmarshal(r, &current)
+		step.ID = id
+		step.Diffs = diffFields(current.Data, payload)
+	}
+
+	synthetic := make(map[string]interface{})
+	for k, v := range payload {
+		synthetic[k] = v
+	}
+	synthetic["id"] = plan.addStep(step)
+	b, err := json.Marshal(synthetic)
+	if err != nil {
+		return fmt.Errorf("Unable to plan entity: %v", err)
+	}
+	return json.Unmarshal(b, result)
+}
+
+// planCall records a call to a non-Crud endpoint in plan instead of sending it.
+// If the call creates an entity (ie values include an "obj"), decodes that entity with a synthetic ID into result
+func (c *Client) planCall(plan *Plan, endpoint string, values map[string]interface{}, result interface{}) error {
+	if c.isClosed() {
+		return ErrClientClosed
+	}
+	b, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("Unable to plan call to %v: %v", endpoint, err)
+	}
+	var payload map[string]interface{}
+	json.Unmarshal(b, &payload)
+	action := strings.TrimSuffix(endpoint, ".json")
+	id := plan.addStep(PlanStep{Action: action, Endpoint: endpoint, Payload: payload})
+
+	obj, ok := payload["obj"].(map[string]interface{})
+	if result == nil || !ok {
+		return nil
+	}
+	obj["id"] = id
+	b, err = json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("Unable to plan call to %v: %v", endpoint, err)
+	}
+	return json.Unmarshal(b, result)
+}
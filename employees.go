@@ -0,0 +1,82 @@
+package bdc
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type employeeResponse struct {
+	Data []Employee `json:"response_data"`
+}
+
+type employeeResp struct {
+	Data Employee `json:"response_data"`
+}
+
+// Employee in Bill.com, used to track expenses by employee
+type Employee struct {
+	Entity      string       `json:"entity"`
+	CreatedTime Timestamp    `json:"createdTime"`
+	UpdatedTime Timestamp    `json:"updatedTime"`
+	IsActive    ActiveStatus `json:"isActive"`
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	ShortName   string       `json:"shortName"`
+	Description string       `json:"description"`
+	ParentID    string       `json:"parentEmployeeId"`
+}
+
+type employeeResource struct {
+	resourceFields
+}
+
+// Get returns a single Employee object
+func (r employeeResource) Get(id string) (Employee, error) {
+	b, err := r.client.getOne(r.suffix, id)
+	if err != nil {
+		return Employee{}, fmt.Errorf("Unable to get employee id %v: %v", id, err)
+	}
+	var goodResp employeeResp
+	if err := json.Unmarshal(b, &goodResp); err != nil {
+		return Employee{}, fmt.Errorf("Unable to read employee id %v: %v", id, err)
+	}
+	return goodResp.Data, nil
+}
+
+// All employees
+func (r employeeResource) All(parameters ...*Parameters) ([]Employee, error) {
+	results := r.client.getAll(r.suffix, parameters)
+
+	var retList []Employee
+	var errSlice []string
+	for _, resp := range results {
+		if resp.err != nil {
+			errSlice = append(errSlice, fmt.Sprintf("Error on page %v: %v", resp.page, resp.err))
+		} else {
+			var goodResp employeeResponse
+			if err := json.Unmarshal(resp.result, &goodResp); err != nil {
+				errSlice = append(errSlice, fmt.Sprintf("Unable to read page %v: %v", resp.page, err))
+				continue
+			}
+			retList = append(retList, goodResp.Data...)
+		}
+	}
+	err := handleErrSlice(errSlice)
+
+	return retList, err
+}
+
+// Since returns all employees updated since the time provided.
+// If no additional params to provide, must pass nil explicitly
+func (r employeeResource) Since(t time.Time, p *Parameters) ([]Employee, error) {
+	if p == nil {
+		p = NewParameters()
+	}
+	p.AddFilter("updatedTime", ">", t.Format(TimeFormat))
+	results, err := r.client.Employee.All(p)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get all employees updated since %s: %v", t, err)
+	}
+	return results, nil
+}
@@ -0,0 +1,194 @@
+package bdc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ActiveStatus of a Bill.com entity. Bill.com never deletes entities; it marks them inactive instead
+type ActiveStatus string
+
+// ActiveStatus options
+const (
+	Active   ActiveStatus = "1"
+	Inactive ActiveStatus = "2"
+)
+
+var activeStatusNames = map[ActiveStatus]string{
+	Active:   "Active",
+	Inactive: "Inactive",
+}
+
+func (s ActiveStatus) String() string { return enumName(string(s), activeStatusNames[s]) }
+
+// UnmarshalJSON accepts the status as a string or a number
+func (s *ActiveStatus) UnmarshalJSON(b []byte) error {
+	*s = ActiveStatus(enumCode(b))
+	return nil
+}
+
+// InvoicePaymentStatus is how much of an invoice (or bill) has been paid
+type InvoicePaymentStatus string
+
+// InvoicePaymentStatus options
+const (
+	InvoicePaid          InvoicePaymentStatus = "0"
+	InvoiceOpen          InvoicePaymentStatus = "1"
+	InvoicePartiallyPaid InvoicePaymentStatus = "2"
+	InvoiceScheduled     InvoicePaymentStatus = "4"
+)
+
+var invoicePaymentStatusNames = map[InvoicePaymentStatus]string{
+	InvoicePaid:          "Paid",
+	InvoiceOpen:          "Open",
+	InvoicePartiallyPaid: "PartiallyPaid",
+	InvoiceScheduled:     "Scheduled",
+}
+
+func (s InvoicePaymentStatus) String() string {
+	return enumName(string(s), invoicePaymentStatusNames[s])
+}
+
+// UnmarshalJSON accepts the status as a string or a number
+func (s *InvoicePaymentStatus) UnmarshalJSON(b []byte) error {
+	*s = InvoicePaymentStatus(enumCode(b))
+	return nil
+}
+
+// PaymentStatus of a payment received, or of its application to a single invoice
+type PaymentStatus string
+
+// PaymentStatus options. PaymentInitiated applies only to the InvoicePays of a payment received
+const (
+	PaymentPaid      PaymentStatus = "0"
+	PaymentVoid      PaymentStatus = "1"
+	PaymentScheduled PaymentStatus = "2"
+	PaymentCanceled  PaymentStatus = "3"
+	PaymentInitiated PaymentStatus = "4"
+)
+
+var paymentStatusNames = map[PaymentStatus]string{
+	PaymentPaid:      "Paid",
+	PaymentVoid:      "Void",
+	PaymentScheduled: "Scheduled",
+	PaymentCanceled:  "Canceled",
+	PaymentInitiated: "Initiated",
+}
+
+func (s PaymentStatus) String() string { return enumName(string(s), paymentStatusNames[s]) }
+
+// UnmarshalJSON accepts the status as a string or a number
+func (s *PaymentStatus) UnmarshalJSON(b []byte) error {
+	*s = PaymentStatus(enumCode(b))
+	return nil
+}
+
+// PaymentType is how a payment received was paid
+type PaymentType string
+
+// PaymentType options
+const (
+	PaymentCash       PaymentType = "0"
+	PaymentCheck      PaymentType = "1"
+	PaymentCreditCard PaymentType = "2"
+	PaymentACH        PaymentType = "3"
+	PaymentPayPal     PaymentType = "4"
+	PaymentOther      PaymentType = "5"
+)
+
+var paymentTypeNames = map[PaymentType]string{
+	PaymentCash:       "Cash",
+	PaymentCheck:      "Check",
+	PaymentCreditCard: "CreditCard",
+	PaymentACH:        "ACH",
+	PaymentPayPal:     "PayPal",
+	PaymentOther:      "Other",
+}
+
+func (t PaymentType) String() string { return enumName(string(t), paymentTypeNames[t]) }
+
+// UnmarshalJSON accepts the type as a string or a number
+func (t *PaymentType) UnmarshalJSON(b []byte) error {
+	*t = PaymentType(enumCode(b))
+	return nil
+}
+
+// valid reports whether t is a known payment type
+func (t PaymentType) valid() bool {
+	_, ok := paymentTypeNames[t]
+	return ok
+}
+
+// ApprovalStatus is where a bill is in its approval workflow
+type ApprovalStatus string
+
+// ApprovalStatus options
+const (
+	ApprovalUnassigned ApprovalStatus = "0"
+	ApprovalAssigned   ApprovalStatus = "1"
+	ApprovalApproved   ApprovalStatus = "3"
+	ApprovalApproving  ApprovalStatus = "4"
+	ApprovalDenied     ApprovalStatus = "5"
+)
+
+var approvalStatusNames = map[ApprovalStatus]string{
+	ApprovalUnassigned: "Unassigned",
+	ApprovalAssigned:   "Assigned",
+	ApprovalApproved:   "Approved",
+	ApprovalApproving:  "Approving",
+	ApprovalDenied:     "Denied",
+}
+
+func (s ApprovalStatus) String() string { return enumName(string(s), approvalStatusNames[s]) }
+
+// UnmarshalJSON accepts the status as a string or a number
+func (s *ApprovalStatus) UnmarshalJSON(b []byte) error {
+	*s = ApprovalStatus(enumCode(b))
+	return nil
+}
+
+// ApproverStatus is where a single approver is in a bill's approval workflow
+type ApproverStatus string
+
+// ApproverStatus options
+const (
+	ApproverUpcoming ApproverStatus = "0"
+	ApproverWaiting  ApproverStatus = "1"
+	ApproverApproved ApproverStatus = "3"
+	ApproverDenied   ApproverStatus = "5"
+)
+
+var approverStatusNames = map[ApproverStatus]string{
+	ApproverUpcoming: "Upcoming",
+	ApproverWaiting:  "Waiting",
+	ApproverApproved: "Approved",
+	ApproverDenied:   "Denied",
+}
+
+func (s ApproverStatus) String() string { return enumName(string(s), approverStatusNames[s]) }
+
+// UnmarshalJSON accepts the status as a string or a number
+func (s *ApproverStatus) UnmarshalJSON(b []byte) error {
+	*s = ApproverStatus(enumCode(b))
+	return nil
+}
+
+// enumName returns the name of a known code, or the code itself if unknown
+func enumName(code, name string) string {
+	if name != "" {
+		return name
+	}
+	if code == "" {
+		return ""
+	}
+	return fmt.Sprintf("Unknown(%s)", code)
+}
+
+// enumCode reads a code that Bill.com may send as a JSON string or number; null reads as empty
+func enumCode(b []byte) string {
+	s := string(b)
+	if s == "null" {
+		return ""
+	}
+	return strings.Trim(s, `"`)
+}
@@ -15,6 +15,9 @@ type errorResponse struct {
 	} `json:"response_data"`
 }
 
+// ErrClientClosed is returned by every call on a client after c.Close()
+var ErrClientClosed = errors.New("bdc: client is closed")
+
 func handleError(r []byte, url string) error {
 	var badResp errorResponse
 	json.Unmarshal(r, &badResp)
@@ -34,3 +37,33 @@ func handleErrSlice(errSlice []string) error {
 	}
 	return err
 }
+
+// ConflictError is returned by an update made with bdc.IfUnchanged
+// when the entity was modified in Bill.com after the version the update was based on.
+// Base and Current are the same type (eg Invoice)
+type ConflictError struct {
+	ID                 string
+	Base               interface{} // version the update was based on
+	Current            interface{} // version currently stored in Bill.com
+	BaseUpdatedTime    string
+	CurrentUpdatedTime string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("Conflicting change: %s was updated at %s, after the version this update was based on (updated at %s)",
+		e.ID, e.CurrentUpdatedTime, e.BaseUpdatedTime)
+}
+
+// UnreadableResultError is returned when Bill.com accepted a create or update
+// but its response could not be decoded. The write happened, so it must not be retried blindly;
+// ID is the ID of the written entity, if the response included one
+type UnreadableResultError struct {
+	Action string // "Created" or "Updated"
+	Suffix string
+	ID     string
+	Err    error
+}
+
+func (e *UnreadableResultError) Error() string {
+	return fmt.Sprintf("%s entity %s at %s, but unable to read response: %v", e.Action, e.ID, e.Suffix, e.Err)
+}
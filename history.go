@@ -5,15 +5,70 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"strings"
+	"sync"
 	"time"
 )
 
-// writeToHistory writes the outcome of a function call to the history file
-func writeToHistory(msg string) error {
+// historyMu serializes writes to the history file across goroutines and clients
+var historyMu sync.Mutex
+
+// fields whose values are masked wherever they are written out, eg in the history file or a dry-run plan
+var sensitiveFields = map[string]bool{
+	"accountNumber": true,
+	"routingNumber": true,
+}
+
+// maskSensitive returns a copy of v (as decoded from JSON) in which the values of sensitiveFields
+// are masked down to their last 4 characters
+func maskSensitive(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		masked := make(map[string]interface{}, len(v))
+		for name, value := range v {
+			if sensitiveFields[name] {
+				masked[name] = maskValue(value)
+			} else {
+				masked[name] = maskSensitive(value)
+			}
+		}
+		return masked
+	case []interface{}:
+		masked := make([]interface{}, len(v))
+		for i, value := range v {
+			masked[i] = maskSensitive(value)
+		}
+		return masked
+	default:
+		return v
+	}
+}
+
+// mask a single sensitive value, eg "123456789" -> "*****6789"
+func maskValue(v interface{}) interface{} {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return v
+	}
+	if len(s) <= 4 {
+		return strings.Repeat("*", len(s))
+	}
+	return strings.Repeat("*", len(s)-4) + s[len(s)-4:]
+}
+
+// writeToHistory writes the outcome of a function call to the history file.
+// In dry-run mode, the message is recorded in the client's plan instead
+func (c *Client) writeToHistory(msg string) error {
+	if plan := c.dryRunPlan(); plan != nil {
+		plan.addNote(msg)
+		return nil
+	}
 	if !showHistory {
 		log.Println(msg)
 		return nil
 	}
+	historyMu.Lock()
+	defer historyMu.Unlock()
 	if _, err := os.Stat(historyPath); os.IsNotExist(err) {
 		ioutil.WriteFile(historyPath, nil, 0666)
 	}
@@ -0,0 +1,31 @@
+package bdc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMaskSensitive(t *testing.T) {
+	in := map[string]interface{}{
+		"nameOnAcct":    "Jane Doe",
+		"accountNumber": "123456789",
+		"obj": map[string]interface{}{
+			"routingNumber": "021000021",
+			"accounts":      []interface{}{map[string]interface{}{"accountNumber": "987"}},
+		},
+	}
+	want := map[string]interface{}{
+		"nameOnAcct":    "Jane Doe",
+		"accountNumber": "*****6789",
+		"obj": map[string]interface{}{
+			"routingNumber": "*****0021",
+			"accounts":      []interface{}{map[string]interface{}{"accountNumber": "***"}},
+		},
+	}
+	if got := maskSensitive(in); !reflect.DeepEqual(got, want) {
+		t.Errorf("maskSensitive = %v, want %v", got, want)
+	}
+	if in["accountNumber"] != "123456789" {
+		t.Error("maskSensitive changed its input")
+	}
+}
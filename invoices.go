@@ -3,7 +3,7 @@ package bdc
 import (
 	"encoding/json"
 	"fmt"
-	"reflect"
+	"sort"
 	"time"
 )
 
@@ -22,45 +22,128 @@ type Invoice struct {
 	Entity string `json:"entity"`
 	ID     string `json:"id"`
 	// 1. Active; 2. Inactive
-	IsActive      string  `json:"isActive"`
-	CreatedTime   string  `json:"createdTime"`
-	UpdatedTime   string  `json:"updatedTime"`
-	CustomerID    string  `json:"customerId"`
-	InvoiceNumber string  `json:"invoiceNumber"`
-	InvoiceDate   string  `json:"invoiceDate"`
-	DueDate       string  `json:"dueDate"`
-	GLPostingDate string  `json:"glPostingDate"`
-	Amount        float64 `json:"amount"`
-	AmountDue     float64 `json:"amountDue"`
-	PaymentStatus string  `json:"paymentStatus"`
-	Description   string  `json:"description"`
-	PONumber      string  `json:"poNumber"`
-	IsToBePrinted bool    `json:"isToBePrinted"`
-	IsToBeEmailed bool    `json:"isToBeEmailed"`
-	// LastSentTime       string            `json:"lastSentTime"`
-	ItemSalesTax       string            `json:"itemSalesTax"`
-	SalesTaxPercentage int               `json:"salesTaxPercentage"`
-	SalesTaxTotal      float64           `json:"salesTaxTotal"`
-	Terms              string            `json:"terms"`
-	ShipDate           string            `json:"shipDate"`
-	ShipMethod         string            `json:"shipMethod"`
-	DepartmentID       string            `json:"departmentId"`
-	LocationID         string            `json:"locationId"`
-	ClassID            string            `json:"actgClassId"`
-	JobID              string            `json:"jobId"`
-	LineItems          []InvoiceLineItem `json:"invoiceLineItems"`
+	IsActive              ActiveStatus         `json:"isActive"`
+	CreatedTime           Timestamp            `json:"createdTime"`
+	UpdatedTime           Timestamp            `json:"updatedTime"`
+	CustomerID            string               `json:"customerId"`
+	InvoiceNumber         string               `json:"invoiceNumber"`
+	InvoiceDate           Date                 `json:"invoiceDate"`
+	DueDate               Date                 `json:"dueDate"`
+	GLPostingDate         Date                 `json:"glPostingDate"`
+	Amount                Money                `json:"amount"`
+	AmountDue             Money                `json:"amountDue"`
+	PaymentStatus         InvoicePaymentStatus `json:"paymentStatus"`
+	Description           string               `json:"description"`
+	PONumber              string               `json:"poNumber"`
+	IsToBePrinted         bool                 `json:"isToBePrinted"`
+	IsToBeEmailed         bool                 `json:"isToBeEmailed"`
+	LastSentTime          Timestamp            `json:"lastSentTime"`
+	ItemSalesTax          string               `json:"itemSalesTax"`
+	SalesTaxPercentage    int                  `json:"salesTaxPercentage"`
+	SalesTaxTotal         Money                `json:"salesTaxTotal"`
+	Terms                 string               `json:"terms"`
+	SalesRep              string               `json:"salesRep"`
+	FOB                   string               `json:"FOB"`
+	ShipDate              Date                 `json:"shipDate"`
+	ShipMethod            string               `json:"shipMethod"`
+	DepartmentID          string               `json:"departmentId"`
+	LocationID            string               `json:"locationId"`
+	ClassID               string               `json:"actgClassId"`
+	JobID                 string               `json:"jobId"`
+	PayToBankAccountID    string               `json:"payToBankAccountId"`
+	PayToChartOfAccountID string               `json:"payToChartOfAccountId"`
+	InvoiceTemplateID     string               `json:"invoiceTemplateId"`
+	LineItems             []InvoiceLineItem    `json:"invoiceLineItems"`
+	// fields returned by Bill.com that are not modeled above, sent back unchanged on update
+	Extra map[string]json.RawMessage `json:"-"`
+	nulls map[string]bool            // modeled fields that Bill.com returned as null
 }
 
 // InvoiceLineItem on a Bill.com invoice
 type InvoiceLineItem struct {
-	Entity      string  `json:"entity"`
-	ItemID      string  `json:"itemId"`
-	Quantity    int     `json:"quantity"`
-	Amount      float64 `json:"amount"`
-	Price       float64 `json:"price"`
-	ClassID     string  `json:"actgClassId"`
-	LocationID  string  `json:"locationId"`
-	Description string  `json:"description"`
+	Entity           string    `json:"entity"`
+	ID               string    `json:"id"`
+	CreatedTime      Timestamp `json:"createdTime"`
+	UpdatedTime      Timestamp `json:"updatedTime"`
+	InvoiceID        string    `json:"invoiceId"`
+	ItemID           string    `json:"itemId"`
+	Quantity         int       `json:"quantity"`
+	Amount           Money     `json:"amount"`
+	Price            Money     `json:"price"`
+	ServiceDate      Date      `json:"serviceDate"`
+	RatePercent      float64   `json:"ratePercent"`
+	ChartOfAccountID string    `json:"chartOfAccountId"`
+	DepartmentID     string    `json:"departmentId"`
+	LocationID       string    `json:"locationId"`
+	ClassID          string    `json:"actgClassId"`
+	JobID            string    `json:"jobId"`
+	Description      string    `json:"description"`
+	Taxable          bool      `json:"taxable"`
+	TaxCode          string    `json:"taxCode"`
+	// fields returned by Bill.com that are not modeled above, sent back unchanged on update
+	Extra map[string]json.RawMessage `json:"-"`
+	nulls map[string]bool            // modeled fields that Bill.com returned as null
+}
+
+type invoiceFields Invoice
+type invoiceLineItemFields InvoiceLineItem
+
+// UnmarshalJSON decodes an invoice, keeping any fields that Invoice does not model in Extra
+func (inv *Invoice) UnmarshalJSON(b []byte) error {
+	var fields invoiceFields
+	extra, nulls, err := decodeLossless(b, &fields)
+	if err != nil {
+		return err
+	}
+	*inv = Invoice(fields)
+	inv.Extra, inv.nulls = extra, nulls
+	return nil
+}
+
+// MarshalJSON encodes an invoice together with its Extra fields.
+// Fields that Bill.com returned as null and have not been changed are encoded as null
+func (inv Invoice) MarshalJSON() ([]byte, error) {
+	return encodeLossless(invoiceFields(inv), inv.Extra, inv.nulls)
+}
+
+// UnmarshalJSON decodes an invoice line item, keeping any fields that InvoiceLineItem does not model in Extra
+func (li *InvoiceLineItem) UnmarshalJSON(b []byte) error {
+	var fields invoiceLineItemFields
+	extra, nulls, err := decodeLossless(b, &fields)
+	if err != nil {
+		return err
+	}
+	*li = InvoiceLineItem(fields)
+	li.Extra, li.nulls = extra, nulls
+	return nil
+}
+
+// MarshalJSON encodes an invoice line item together with its Extra fields.
+// Fields that Bill.com returned as null and have not been changed are encoded as null
+func (li InvoiceLineItem) MarshalJSON() ([]byte, error) {
+	return encodeLossless(invoiceLineItemFields(li), li.Extra, li.nulls)
+}
+
+// forNewInvoice returns a copy of a line item for use on another invoice, without the IDs and times that tie it to this one
+func (li InvoiceLineItem) forNewInvoice() InvoiceLineItem {
+	li.ID, li.InvoiceID = "", ""
+	li.CreatedTime, li.UpdatedTime = Timestamp{}, Timestamp{}
+	// the copy must not share its maps with the original
+	if li.Extra != nil {
+		extra := make(map[string]json.RawMessage, len(li.Extra))
+		for name, value := range li.Extra {
+			extra[name] = append(json.RawMessage(nil), value...)
+		}
+		li.Extra = extra
+	}
+	if li.nulls != nil {
+		nulls := make(map[string]bool, len(li.nulls))
+		for name := range li.nulls {
+			nulls[name] = true
+		}
+		li.nulls = nulls
+	}
+	return li
 }
 
 type invoiceResource struct {
@@ -78,7 +161,10 @@ func (r invoiceResource) All(parameters ...*Parameters) ([]Invoice, error) {
 			errSlice = append(errSlice, fmt.Sprintf("Error on page %v: %v", resp.page, resp.err))
 		} else {
 			var goodResp invoiceRespList
-			json.Unmarshal(resp.result, &goodResp)
+			if err := json.Unmarshal(resp.result, &goodResp); err != nil {
+				errSlice = append(errSlice, fmt.Sprintf("Unable to read page %v: %v", resp.page, err))
+				continue
+			}
 			retList = append(retList, goodResp.Data...)
 		}
 	}
@@ -94,18 +180,190 @@ func (r invoiceResource) Get(id string) (Invoice, error) {
 		return Invoice{}, fmt.Errorf("Unable to get invoice id %v: %v", id, err)
 	}
 	var goodResp invoiceResp
-	json.Unmarshal(inv, &goodResp)
+	if err := json.Unmarshal(inv, &goodResp); err != nil {
+		return Invoice{}, fmt.Errorf("Unable to read invoice id %v: %v", id, err)
+	}
 	return goodResp.Data, nil
 }
 
-// Create invoice
-func (r invoiceResource) Create(inv Invoice) error {
-	conf, err := r.client.createEntity(r.suffix, inv)
+// Create invoice.
+// Returns the new invoice as stored by Bill.com, including its ID and server-computed fields
+func (r invoiceResource) Create(inv Invoice) (Invoice, error) {
+	var created Invoice
+	err := r.client.createEntity(r.suffix, inv, &created)
 	if err != nil {
-		return fmt.Errorf("Unable to create invoice %s for customer %s in amount %.2f: %v", inv.ID, inv.CustomerID, inv.Amount, err)
+		if unreadable, ok := err.(*UnreadableResultError); ok {
+			return Invoice{}, unreadable
+		}
+		return Invoice{}, fmt.Errorf("Unable to create invoice %s for customer %s in amount %v: %v", inv.InvoiceNumber, inv.CustomerID, inv.Amount, err)
 	}
-	writeToHistory(fmt.Sprintf("Created invoice: %s", conf))
-	return nil
+	r.client.writeToHistory(fmt.Sprintf("Created invoice %s (number %s) for customer %s in amount %v",
+		created.ID, created.InvoiceNumber, created.CustomerID, created.Amount))
+	return created, nil
+}
+
+// UpsertPolicy determines what Upsert does when a matching invoice already exists
+type UpsertPolicy int
+
+// Upsert policy options
+// bdc.SkipExisting: leave the existing invoice untouched and return it.
+// bdc.UpdateExisting: overwrite the existing invoice with the values provided.
+// bdc.ErrorOnExisting: return an error.
+const (
+	SkipExisting UpsertPolicy = iota
+	UpdateExisting
+	ErrorOnExisting
+)
+
+// fields that Bill.com computes or manages itself, which Upsert never copies onto an existing invoice
+var upsertSkipFields = map[string]bool{
+	"amountDue":     true,
+	"paymentStatus": true,
+}
+
+// UpsertOutcome records what Upsert did with an invoice
+type UpsertOutcome int
+
+// Upsert outcomes
+const (
+	UpsertCreated UpsertOutcome = iota
+	UpsertUpdated
+	UpsertSkipped
+)
+
+func (o UpsertOutcome) String() string {
+	switch o {
+	case UpsertCreated:
+		return "Created"
+	case UpsertUpdated:
+		return "Updated"
+	case UpsertSkipped:
+		return "Skipped"
+	}
+	return fmt.Sprintf("UpsertOutcome(%d)", int(o))
+}
+
+// UpsertResult is an invoice as stored by Bill.com after an upsert, and what the upsert did with it
+type UpsertResult struct {
+	Invoice Invoice
+	Outcome UpsertOutcome
+}
+
+// NewlyCreated returns the invoices that were created (rather than updated or skipped) by an upsert,
+// eg to send only new invoices after re-running a CSV import
+func NewlyCreated(results []UpsertResult) []Invoice {
+	var created []Invoice
+	for _, r := range results {
+		if r.Outcome == UpsertCreated {
+			created = append(created, r.Invoice)
+		}
+	}
+	return created
+}
+
+// FindByNumber returns the active invoice for a customer with the invoice number provided.
+// Returns false if no such invoice exists
+func (r invoiceResource) FindByNumber(customerID, invoiceNumber string) (Invoice, bool, error) {
+	p := NewParameters()
+	p.AddFilter("isActive", "=", Active)
+	p.AddFilter("customerId", "=", customerID)
+	p.AddFilter("invoiceNumber", "=", invoiceNumber)
+	inv, err := r.client.Invoice.All(p)
+	if err != nil {
+		return Invoice{}, false, fmt.Errorf("Unable to look up invoice number %s for customer %s: %v", invoiceNumber, customerID, err)
+	}
+	for _, i := range inv {
+		// guard against partial matches in case the server does not filter exactly
+		if i.CustomerID == customerID && i.InvoiceNumber == invoiceNumber {
+			return i, true, nil
+		}
+	}
+	return Invoice{}, false, nil
+}
+
+// Upsert creates an invoice unless an active invoice with the same customer and invoice number already exists,
+// in which case it skips, updates or errors according to policy.
+// On update, only the fields of inv that hold a non-zero value are copied onto the existing invoice,
+// so a sparse invoice never resets false, 0 or empty fields; server-managed fields are never copied.
+// To clear a field or set it to false or 0, use Update with Set.
+// Returns the invoice as stored by Bill.com and whether it was created, updated or skipped
+func (r invoiceResource) Upsert(inv Invoice, policy UpsertPolicy) (UpsertResult, error) {
+	if inv.CustomerID == "" || inv.InvoiceNumber == "" {
+		return UpsertResult{}, fmt.Errorf("Unable to upsert invoice: must provide customer ID and invoice number")
+	}
+	existing, ok, err := r.FindByNumber(inv.CustomerID, inv.InvoiceNumber)
+	if err != nil {
+		return UpsertResult{}, fmt.Errorf("Unable to upsert invoice: %v", err)
+	}
+	if !ok {
+		created, err := r.Create(inv)
+		if err != nil {
+			return UpsertResult{}, err
+		}
+		return UpsertResult{Invoice: created, Outcome: UpsertCreated}, nil
+	}
+	switch policy {
+	case SkipExisting:
+		r.client.writeToHistory(fmt.Sprintf("Skipped existing invoice %s (number %s) for customer %s", existing.ID, existing.InvoiceNumber, existing.CustomerID))
+		return UpsertResult{Invoice: existing, Outcome: UpsertSkipped}, nil
+	case UpdateExisting:
+		fields, err := invoiceUpsertFields(inv)
+		if err != nil {
+			return UpsertResult{}, fmt.Errorf("Unable to upsert invoice number %s: %v", inv.InvoiceNumber, err)
+		}
+		updated, err := r.Update(existing.ID, fields...)
+		if err != nil {
+			return UpsertResult{}, err
+		}
+		return UpsertResult{Invoice: updated, Outcome: UpsertUpdated}, nil
+	case ErrorOnExisting:
+		return UpsertResult{}, fmt.Errorf("Unable to upsert invoice: invoice number %s already exists for customer %s (ID: %s)", inv.InvoiceNumber, inv.CustomerID, existing.ID)
+	default:
+		return UpsertResult{}, fmt.Errorf("Unable to upsert invoice: unknown policy %v", policy)
+	}
+}
+
+// convert an invoice into the Fields that Upsert copies onto an existing invoice
+func invoiceUpsertFields(inv Invoice) ([]Field, error) {
+	b, err := json.Marshal(inv)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to encode invoice: %v", err)
+	}
+	var m map[string]interface{}
+	err = json.Unmarshal(b, &m)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to decode invoice: %v", err)
+	}
+	var fields []Field
+	for name, value := range m {
+		if readOnlyFields[name] || upsertSkipFields[name] || isZeroValue(value) {
+			continue
+		}
+		fields = append(fields, Set(name, value))
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].name < fields[j].name
+	})
+	return fields, nil
+}
+
+// isZeroValue reports whether a decoded JSON value is null or the zero value of its type
+func isZeroValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
 }
 
 // Since returns all invoices updated since the time provided.
@@ -139,62 +397,75 @@ func (r invoiceResource) SinceFileTime(filePath string, params *Parameters) ([]I
 }
 
 // Update one invoice.
-// Supply an Invoice with just the updates you want; all other fields will be preserved.
-// Must supply an ID
-func (r invoiceResource) Update(updates Invoice) error {
-	if updates.ID == "" {
-		return fmt.Errorf("Must provide invoice ID to update")
-	}
-	oldInvoice, err := r.client.Invoice.Get(updates.ID)
+// Supply the invoice ID and only the fields to change, eg bdc.Set("isToBeEmailed", false);
+// all other fields will be preserved. Zero and empty values are applied as is.
+// To guard against overwriting someone else's changes, include bdc.IfUnchanged(base).
+// Returns the updated invoice as stored by Bill.com
+func (r invoiceResource) Update(id string, fields ...Field) (Invoice, error) {
+	var updated Invoice
+	changes, err := r.client.patchEntity(r.suffix, id, fields, &updated)
 	if err != nil {
-		return fmt.Errorf("Unable to get invoice %v to run update: %v", updates.ID, err)
-	}
-	newInvoice := Invoice(oldInvoice)
-	nonZeroUpdates := make(map[string]interface{})
-	valUpdates := reflect.ValueOf(updates)
-	for i := 0; i < valUpdates.NumField(); i++ {
-		fVal := valUpdates.Field(i)
-		fType := fVal.Type()
-		fName := valUpdates.Type().Field(i).Name
-
-		var isZero bool
-		switch fType.Kind() {
-		case reflect.Slice: // handle LineItems
-			isZero = fVal.Len() == 0
-		default:
-			isZero = fVal.Interface() == reflect.Zero(fType).Interface()
+		if conflict, ok := err.(*ConflictError); ok {
+			return Invoice{}, conflict
 		}
-		if isZero {
-			continue
+		if unreadable, ok := err.(*UnreadableResultError); ok {
+			return Invoice{}, unreadable
 		}
-		nonZeroUpdates[fName] = fVal
-		reflect.ValueOf(&newInvoice).Elem().FieldByName(fName).Set(fVal)
+		return Invoice{}, fmt.Errorf("Unable to update invoice %v: %v", id, err)
 	}
+	r.client.writeToHistory(fmt.Sprintf("Updated invoice %s: %s", updated.ID, changes))
+	return updated, nil
+}
 
-	conf, err := r.client.updateEntity(r.suffix, newInvoice)
-	if err != nil {
-		return fmt.Errorf("Unable to make these invoice changes: %v: %v", nonZeroUpdates, err)
+// UpdateFunc updates one invoice without overwriting changes made by anyone else in the meantime.
+// merge is called with the current invoice and returns the fields to change.
+// If the invoice is modified in Bill.com before those changes are written,
+// merge is called again with the newer version, up to retries more times.
+// If the invoice is still conflicting after that, returns the *ConflictError
+func (r invoiceResource) UpdateFunc(id string, merge func(current Invoice) ([]Field, error), retries int) (Invoice, error) {
+	for attempt := 0; ; attempt++ {
+		current, err := r.Get(id)
+		if err != nil {
+			return Invoice{}, fmt.Errorf("Unable to update invoice %v: %v", id, err)
+		}
+		fields, err := merge(current)
+		if err != nil {
+			return Invoice{}, fmt.Errorf("Unable to merge changes into invoice %v: %v", id, err)
+		}
+		updated, err := r.Update(id, append(fields, IfUnchanged(current))...)
+		if _, ok := err.(*ConflictError); ok && attempt < retries {
+			continue
+		}
+		return updated, err
 	}
-	writeToHistory(fmt.Sprintf("Made these updates: %v. Full invoice: %s", nonZeroUpdates, conf))
-	return nil
-
 }
 
 // NewInvoiceLineItem returns a pointer to a new invoice line item
 // Only allows for a quantity of 1 per invoice line item
 // identifierTypes must be one of: default (i.e., Bill.com-provided values), custom (client-provided values)
-func NewInvoiceLineItem(identifierTypes string, itemName string, amount float64, description string) (InvoiceLineItem, error) {
+// Custom values are looked up in the default mappings directory; for a client of another organization,
+// use c.NewInvoiceLineItem
+func NewInvoiceLineItem(identifierTypes string, itemName string, amount Money, description string) (InvoiceLineItem, error) {
+	return newInvoiceLineItem(mappingsDir, identifierTypes, itemName, amount, description)
+}
+
+// NewInvoiceLineItem returns a new invoice line item, looking up custom values in the client's mappings directory
+func (c *Client) NewInvoiceLineItem(identifierTypes string, itemName string, amount Money, description string) (InvoiceLineItem, error) {
+	return newInvoiceLineItem(c.mappingsDir, identifierTypes, itemName, amount, description)
+}
+
+func newInvoiceLineItem(dir string, identifierTypes string, itemName string, amount Money, description string) (InvoiceLineItem, error) {
 	var item string
 	switch identifierTypes {
 	case "custom":
-		maps, err := getItemsMapping()
+		maps, err := getItemsMapping(dir)
 		var ok bool
 		if err != nil {
 			return InvoiceLineItem{}, fmt.Errorf("Unable to get items mapping: %v", err)
 		}
 		item, ok = maps[itemName]
 		if !ok {
-			return InvoiceLineItem{}, fmt.Errorf("Item %v not in mapping. Check file in %v for valid mappings line item and run client.UpdateAllMappingFiles if necessary", itemName, mappingsDir)
+			return InvoiceLineItem{}, fmt.Errorf("Item %v not in mapping. Check file in %v for valid mappings line item and run client.UpdateAllMappingFiles if necessary", itemName, dir)
 		}
 	case "default":
 		item = itemName
@@ -204,6 +475,7 @@ func NewInvoiceLineItem(identifierTypes string, itemName string, amount float64,
 		ItemID:      item,
 		Quantity:    1,
 		Price:       amount,
+		Amount:      amount,
 		Description: description,
 	}, nil
 }
@@ -213,35 +485,72 @@ func NewInvoiceLineItem(identifierTypes string, itemName string, amount float64,
 // InvoiceDate and DueDate are set to be equivalent
 // identifierTypes must be one of: default (i.e., Bill.com-provided values), custom (client-provided values)
 // Best practice is to run c.UpdateInvoiceMappings() prior
+// Custom values are looked up in the default mappings directory; for a client of another organization,
+// use c.NewInvoice.
+// If the client returned by NewClient has cached Invoice metadata (see c.Metadata), the invoice is also validated against it
 func NewInvoice(identifierTypes, customerName, invoiceNumber, dueDate, className, locationName string,
+	lineItems []InvoiceLineItem) (Invoice, error) {
+	inv, err := newInvoice(mappingsDir, identifierTypes, customerName, invoiceNumber, dueDate, className, locationName, lineItems)
+	if err != nil {
+		return Invoice{}, err
+	}
+	if m, ok := client.cachedMetadata(invoiceSuffix); ok {
+		if err := m.Validate(inv); err != nil {
+			return Invoice{}, fmt.Errorf("Invalid invoice: %v", err)
+		}
+	}
+	return inv, nil
+}
+
+// NewInvoice returns a new invoice, looking up custom values in the client's mappings directory.
+// If the client has cached Invoice metadata (see c.Metadata), the invoice is also validated against it
+func (c *Client) NewInvoice(identifierTypes, customerName, invoiceNumber, dueDate, className, locationName string,
+	lineItems []InvoiceLineItem) (Invoice, error) {
+	inv, err := newInvoice(c.mappingsDir, identifierTypes, customerName, invoiceNumber, dueDate, className, locationName, lineItems)
+	if err != nil {
+		return Invoice{}, err
+	}
+	if m, ok := c.cachedMetadata(invoiceSuffix); ok {
+		if err := m.Validate(inv); err != nil {
+			return Invoice{}, fmt.Errorf("Invalid invoice: %v", err)
+		}
+	}
+	return inv, nil
+}
+
+func newInvoice(dir string, identifierTypes, customerName, invoiceNumber, dueDate, className, locationName string,
 	lineItems []InvoiceLineItem) (Invoice, error) {
 	var location, class, customer string
 	switch identifierTypes {
 	case "custom":
-		maps, err := getInvoiceCreationMappings()
+		maps, err := getInvoiceCreationMappings(dir)
 		var ok bool
 		if err != nil {
 			return Invoice{}, fmt.Errorf("Unable to get convenience mappings to create invoice: %v", err)
 		}
 		location, ok = maps[Locations][locationName]
 		if !ok {
-			return Invoice{}, fmt.Errorf("Location %v not in mapping. Check file in %v for valid mappings and run client.UpdateAllMappingFiles if necessary", locationName, mappingsDir)
+			return Invoice{}, fmt.Errorf("Location %v not in mapping. Check file in %v for valid mappings and run client.UpdateAllMappingFiles if necessary", locationName, dir)
 		}
 		class, ok = maps[Classes][className]
 		if !ok {
-			return Invoice{}, fmt.Errorf("Class %v not in mapping. Check file in %v for valid mappings and run client.UpdateAllMappingFiles if necessary", className, mappingsDir)
+			return Invoice{}, fmt.Errorf("Class %v not in mapping. Check file in %v for valid mappings and run client.UpdateAllMappingFiles if necessary", className, dir)
 		}
 		customer, ok = maps[Customers][customerName]
 		if !ok {
-			return Invoice{}, fmt.Errorf("Customer %v not in mapping. Check file in %v for valid mappings and run client.UpdateAllMappingFiles if necessary", customerName, mappingsDir)
+			return Invoice{}, fmt.Errorf("Customer %v not in mapping. Check file in %v for valid mappings and run client.UpdateAllMappingFiles if necessary", customerName, dir)
 		}
 	case "default":
 		location = locationName
 		class = className
 		customer = customerName
 	}
+	due, err := ParseDate(dueDate)
+	if err != nil {
+		return Invoice{}, fmt.Errorf("Unable to create invoice: due date: %v", err)
+	}
 
-	var amount float64
+	var amount Money
 	var lineItemsCopy []InvoiceLineItem
 	for _, lineItem := range lineItems {
 		amount += lineItem.Amount
@@ -254,8 +563,8 @@ func NewInvoice(identifierTypes, customerName, invoiceNumber, dueDate, className
 		Entity:        "Invoice",
 		CustomerID:    customer,
 		InvoiceNumber: invoiceNumber,
-		InvoiceDate:   dueDate,
-		DueDate:       dueDate,
+		InvoiceDate:   due,
+		DueDate:       due,
 		Amount:        amount,
 		AmountDue:     amount, // upon invoice creation, equivalent to amount
 		ClassID:       class,
@@ -12,14 +12,14 @@ type itemResponse struct {
 
 // Item in Bill.com
 type Item struct {
-	Entity      string `json:"entity"`
-	CreatedTime string `json:"createdTime"`
-	UpdatedTime string `json:"updatedTime"`
-	IsActive    string `json:"isActive"`
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	ShortName   string `json:"shortName"`
-	Description string `json:"description"`
+	Entity      string       `json:"entity"`
+	CreatedTime Timestamp    `json:"createdTime"`
+	UpdatedTime Timestamp    `json:"updatedTime"`
+	IsActive    ActiveStatus `json:"isActive"`
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	ShortName   string       `json:"shortName"`
+	Description string       `json:"description"`
 }
 
 type itemResource struct {
@@ -37,7 +37,10 @@ func (r itemResource) All(parameters ...*Parameters) ([]Item, error) {
 			errSlice = append(errSlice, fmt.Sprintf("Error on page %v: %v", resp.page, resp.err))
 		} else {
 			var goodResp itemResponse
-			json.Unmarshal(resp.result, &goodResp)
+			if err := json.Unmarshal(resp.result, &goodResp); err != nil {
+				errSlice = append(errSlice, fmt.Sprintf("Unable to read page %v: %v", resp.page, err))
+				continue
+			}
 			retList = append(retList, goodResp.Data...)
 		}
 	}
@@ -59,3 +62,24 @@ func (r itemResource) Since(t time.Time, p *Parameters) ([]Item, error) {
 	}
 	return items, nil
 }
+
+// Update one item.
+// Supply the item ID and only the fields to change, eg bdc.Set("description", "");
+// all other fields will be preserved. Zero and empty values are applied as is.
+// To guard against overwriting someone else's changes, include bdc.IfUnchanged(base).
+// Returns the updated item as stored by Bill.com
+func (r itemResource) Update(id string, fields ...Field) (Item, error) {
+	var updated Item
+	changes, err := r.client.patchEntity(r.suffix, id, fields, &updated)
+	if err != nil {
+		if conflict, ok := err.(*ConflictError); ok {
+			return Item{}, conflict
+		}
+		if unreadable, ok := err.(*UnreadableResultError); ok {
+			return Item{}, unreadable
+		}
+		return Item{}, fmt.Errorf("Unable to update item %v: %v", id, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Updated item %s: %s", updated.ID, changes))
+	return updated, nil
+}
@@ -0,0 +1,82 @@
+package bdc
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type jobResponse struct {
+	Data []Job `json:"response_data"`
+}
+
+type jobResp struct {
+	Data Job `json:"response_data"`
+}
+
+// Job in Bill.com, used to track income and expenses by project
+type Job struct {
+	Entity      string       `json:"entity"`
+	CreatedTime Timestamp    `json:"createdTime"`
+	UpdatedTime Timestamp    `json:"updatedTime"`
+	IsActive    ActiveStatus `json:"isActive"`
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	ShortName   string       `json:"shortName"`
+	Description string       `json:"description"`
+	ParentID    string       `json:"parentJobId"`
+}
+
+type jobResource struct {
+	resourceFields
+}
+
+// Get returns a single Job object
+func (r jobResource) Get(id string) (Job, error) {
+	b, err := r.client.getOne(r.suffix, id)
+	if err != nil {
+		return Job{}, fmt.Errorf("Unable to get job id %v: %v", id, err)
+	}
+	var goodResp jobResp
+	if err := json.Unmarshal(b, &goodResp); err != nil {
+		return Job{}, fmt.Errorf("Unable to read job id %v: %v", id, err)
+	}
+	return goodResp.Data, nil
+}
+
+// All jobs
+func (r jobResource) All(parameters ...*Parameters) ([]Job, error) {
+	results := r.client.getAll(r.suffix, parameters)
+
+	var retList []Job
+	var errSlice []string
+	for _, resp := range results {
+		if resp.err != nil {
+			errSlice = append(errSlice, fmt.Sprintf("Error on page %v: %v", resp.page, resp.err))
+		} else {
+			var goodResp jobResponse
+			if err := json.Unmarshal(resp.result, &goodResp); err != nil {
+				errSlice = append(errSlice, fmt.Sprintf("Unable to read page %v: %v", resp.page, err))
+				continue
+			}
+			retList = append(retList, goodResp.Data...)
+		}
+	}
+	err := handleErrSlice(errSlice)
+
+	return retList, err
+}
+
+// Since returns all jobs updated since the time provided.
+// If no additional params to provide, must pass nil explicitly
+func (r jobResource) Since(t time.Time, p *Parameters) ([]Job, error) {
+	if p == nil {
+		p = NewParameters()
+	}
+	p.AddFilter("updatedTime", ">", t.Format(TimeFormat))
+	results, err := r.client.Job.All(p)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get all jobs updated since %s: %v", t, err)
+	}
+	return results, nil
+}
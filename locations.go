@@ -12,14 +12,14 @@ type locationResponse struct {
 
 // Location in Bill.com
 type Location struct {
-	Entity      string `json:"entity"`
-	CreatedTime string `json:"createdTime"`
-	UpdatedTime string `json:"updatedTime"`
-	IsActive    string `json:"isActive"`
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	ShortName   string `json:"shortName"`
-	Description string `json:"description"`
+	Entity      string       `json:"entity"`
+	CreatedTime Timestamp    `json:"createdTime"`
+	UpdatedTime Timestamp    `json:"updatedTime"`
+	IsActive    ActiveStatus `json:"isActive"`
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	ShortName   string       `json:"shortName"`
+	Description string       `json:"description"`
 }
 
 type locationResource struct {
@@ -37,7 +37,10 @@ func (r locationResource) All(parameters ...*Parameters) ([]Location, error) {
 			errSlice = append(errSlice, fmt.Sprintf("Error on page %v: %v", resp.page, resp.err))
 		} else {
 			var goodResp locationResponse
-			json.Unmarshal(resp.result, &goodResp)
+			if err := json.Unmarshal(resp.result, &goodResp); err != nil {
+				errSlice = append(errSlice, fmt.Sprintf("Unable to read page %v: %v", resp.page, err))
+				continue
+			}
 			retList = append(retList, goodResp.Data...)
 		}
 	}
@@ -59,3 +62,24 @@ func (r locationResource) Since(t time.Time, p *Parameters) ([]Location, error)
 	}
 	return locations, nil
 }
+
+// Update one location.
+// Supply the location ID and only the fields to change, eg bdc.Set("description", "");
+// all other fields will be preserved. Zero and empty values are applied as is.
+// To guard against overwriting someone else's changes, include bdc.IfUnchanged(base).
+// Returns the updated location as stored by Bill.com
+func (r locationResource) Update(id string, fields ...Field) (Location, error) {
+	var updated Location
+	changes, err := r.client.patchEntity(r.suffix, id, fields, &updated)
+	if err != nil {
+		if conflict, ok := err.(*ConflictError); ok {
+			return Location{}, conflict
+		}
+		if unreadable, ok := err.(*UnreadableResultError); ok {
+			return Location{}, unreadable
+		}
+		return Location{}, fmt.Errorf("Unable to update location %v: %v", id, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Updated location %s: %s", updated.ID, changes))
+	return updated, nil
+}
@@ -10,9 +10,12 @@ import (
 )
 
 type loginResponse struct {
-	Data struct {
-		SessionID string `json:"sessionId"`
-	} `json:"response_data"`
+	Data session `json:"response_data"`
+}
+
+type session struct {
+	SessionID string `json:"sessionId"`
+	UserID    string `json:"usersId"`
 }
 
 var customCreds = &credentials{}
@@ -27,42 +30,80 @@ func Login(username, password, orgID, devKey string) {
 	}
 }
 
-// login returns a session id if successful
-func login(creds *credentials) (string, error) {
+// login returns a session if successful, using the credentials supplied to Login() or else the credentials file.
+// Populates creds with the credentials used
+func login(creds *credentials) (session, error) {
 	// Credentials
 	if customCreds.UserName == "" {
 		f, err := ioutil.ReadFile(credentialsPath)
 		if err != nil {
-			return "", fmt.Errorf("Unable to read credentials file (%q) specified in config file (%q): %s", credentialsPath, configPath, err)
+			return session{}, fmt.Errorf("Unable to read credentials file (%q) specified in config file (%q): %s", credentialsPath, configPath, err)
 		}
 		json.Unmarshal(f, creds)
 	} else {
-		creds = customCreds
+		*creds = *customCreds
 	}
+	return loginWith(*creds, mfaID, mfaDeviceID)
+}
+
+// loginWith returns a session for the credentials provided.
+// If an MFA device has been remembered (see c.AuthenticateMFA), the session is authenticated with it
+func loginWith(creds credentials, mfaID, deviceID string) (session, error) {
 	data := url.Values{}
 	data.Set("userName", creds.UserName)
 	data.Set("password", creds.Password)
 	data.Set("orgId", creds.OrgID)
 	data.Set("devKey", creds.DevKey)
+	if mfaID != "" {
+		data.Set("mfaId", mfaID)
+		data.Set("deviceId", deviceID)
+	}
 	body := strings.NewReader(data.Encode())
 
 	// Request
 	resp, err := http.Post(loginURL, "application/x-www-form-urlencoded", body)
 	if err != nil {
-		return "", fmt.Errorf("Unable to send Post request to %s: %s", loginURL, err)
+		return session{}, fmt.Errorf("Unable to send Post request to %s: %s", loginURL, err)
 	}
 	defer resp.Body.Close()
 	r, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("Unable to read resp body from %s: %s", loginURL, err)
+		return session{}, fmt.Errorf("Unable to read resp body from %s: %s", loginURL, err)
 	}
 	// Handling responses
 	err = handleError(r, loginURL)
 	if err != nil {
-		return "", fmt.Errorf("Unable to log in to Bill.com: %v", err)
+		return session{}, fmt.Errorf("Unable to log in to Bill.com: %v", err)
 	}
 	var goodResp loginResponse
 	json.Unmarshal(r, &goodResp)
 
-	return goodResp.Data.SessionID, nil
+	return goodResp.Data, nil
+}
+
+// Organization that a Bill.com user can log in to
+type Organization struct {
+	ID   string `json:"orgId"`
+	Name string `json:"orgName"`
+}
+
+const listOrgsEndpoint = "ListOrgs.json"
+
+// ListOrganizations returns every organization the user can log in to. Does not require an org ID.
+// Log in to one with bdc.Login() and bdc.NewClient(), or derive a client from an existing one with c.ForOrganization()
+func ListOrganizations(username, password, devKey string) ([]Organization, error) {
+	data := url.Values{}
+	data.Set("userName", username)
+	data.Set("password", password)
+	data.Set("devKey", devKey)
+	r, err := makeRequest(listOrgsEndpoint, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("Unable to list organizations: %v", err)
+	}
+	var orgs []Organization
+	err = decodeEntity(r, &orgs)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to list organizations: %v", err)
+	}
+	return orgs, nil
 }
@@ -0,0 +1,103 @@
+package bdc
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Entities returned by Bill.com may include fields that their structs do not model, and model fields that were null.
+// decodeLossless and encodeLossless carry both through a decode and re-encode unchanged,
+// so that writing back an entity read from Bill.com never clobbers values the client did not touch
+
+var jsonNamesCache sync.Map // reflect.Type -> map[string]bool
+
+// jsonNames returns the JSON names of the fields of struct type t
+func jsonNames(t reflect.Type) map[string]bool {
+	if names, ok := jsonNamesCache.Load(t); ok {
+		return names.(map[string]bool)
+	}
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" { // unexported
+			continue
+		}
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[name] = true
+	}
+	jsonNamesCache.Store(t, names)
+	return names
+}
+
+// decodeLossless decodes b into v, a pointer to a struct without its own UnmarshalJSON method.
+// Returns the fields of b that v does not model, and the names of the fields of v that were null in b
+func decodeLossless(b []byte, v interface{}) (map[string]json.RawMessage, map[string]bool, error) {
+	if err := json.Unmarshal(b, v); err != nil {
+		return nil, nil, err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, nil, err
+	}
+	known := jsonNames(reflect.TypeOf(v).Elem())
+	var extra map[string]json.RawMessage
+	var nulls map[string]bool
+	for name, value := range raw {
+		switch {
+		case !known[name]:
+			if extra == nil {
+				extra = make(map[string]json.RawMessage)
+			}
+			extra[name] = value
+		case string(value) == "null":
+			if nulls == nil {
+				nulls = make(map[string]bool)
+			}
+			nulls[name] = true
+		}
+	}
+	return extra, nulls, nil
+}
+
+// encodeLossless encodes v, a struct without its own MarshalJSON method, together with the extra fields provided.
+// Fields named in nulls are encoded as null as long as they still hold their zero value
+func encodeLossless(v interface{}, extra map[string]json.RawMessage, nulls map[string]bool) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || (len(extra) == 0 && len(nulls) == 0) {
+		return b, err
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	if len(nulls) > 0 {
+		zero, err := json.Marshal(reflect.Zero(reflect.TypeOf(v)).Interface())
+		if err != nil {
+			return nil, err
+		}
+		var zeroes map[string]json.RawMessage
+		if err := json.Unmarshal(zero, &zeroes); err != nil {
+			return nil, err
+		}
+		for name := range nulls {
+			if value, ok := m[name]; ok && bytes.Equal(value, zeroes[name]) {
+				m[name] = json.RawMessage("null")
+			}
+		}
+	}
+	for name, value := range extra {
+		if _, ok := m[name]; !ok {
+			m[name] = value
+		}
+	}
+	return json.Marshal(m)
+}
@@ -0,0 +1,128 @@
+package bdc
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type losslessFields struct {
+	Name   string `json:"name"`
+	Amount Money  `json:"amount"`
+	Note   string `json:"note"`
+}
+
+func TestDecodeLossless(t *testing.T) {
+	in := `{"name":"a","amount":1.50,"note":null,"custom":{"x":1},"flag":true}`
+	var v losslessFields
+	extra, nulls, err := decodeLossless([]byte(in), &v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (losslessFields{Name: "a", Amount: 150}); v != want {
+		t.Errorf("decoded %+v, want %+v", v, want)
+	}
+	wantExtra := map[string]json.RawMessage{"custom": json.RawMessage(`{"x":1}`), "flag": json.RawMessage(`true`)}
+	if !reflect.DeepEqual(extra, wantExtra) {
+		t.Errorf("extra = %s, want %s", extra, wantExtra)
+	}
+	if !reflect.DeepEqual(nulls, map[string]bool{"note": true}) {
+		t.Errorf("nulls = %v, want note", nulls)
+	}
+}
+
+func TestEncodeLossless(t *testing.T) {
+	tests := []struct {
+		name  string
+		v     losslessFields
+		extra map[string]json.RawMessage
+		nulls map[string]bool
+		want  string
+	}{
+		{"no extras", losslessFields{Name: "a"}, nil, nil,
+			`{"amount":0.00,"name":"a","note":""}`},
+		{"unknown fields kept", losslessFields{Name: "a"}, map[string]json.RawMessage{"custom": json.RawMessage(`[1,2]`)}, nil,
+			`{"amount":0.00,"custom":[1,2],"name":"a","note":""}`},
+		{"unchanged null kept", losslessFields{Name: "a"}, nil, map[string]bool{"note": true},
+			`{"amount":0.00,"name":"a","note":null}`},
+		{"changed null wins", losslessFields{Name: "a", Note: "set"}, nil, map[string]bool{"note": true},
+			`{"amount":0.00,"name":"a","note":"set"}`},
+		{"modeled field wins over extra", losslessFields{Name: "new"}, map[string]json.RawMessage{"name": json.RawMessage(`"old"`)}, nil,
+			`{"amount":0.00,"name":"new","note":""}`},
+	}
+	for _, tt := range tests {
+		got, err := encodeLossless(tt.v, tt.extra, tt.nulls)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !jsonEqual(t, got, []byte(tt.want)) {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+// jsonEqual reports whether a and b encode the same value, regardless of key order
+func jsonEqual(t *testing.T, a, b []byte) bool {
+	var va, vb interface{}
+	if err := json.Unmarshal(a, &va); err != nil {
+		t.Fatalf("invalid JSON %s: %v", a, err)
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		t.Fatalf("invalid JSON %s: %v", b, err)
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+func TestInvoiceRoundTrip(t *testing.T) {
+	in := `{"entity":"Invoice","id":"00e1","salesRep":null,"customField":"kept",` +
+		`"invoiceLineItems":[{"entity":"InvoiceLineItem","id":"0il1","taxCode":null,"lineCustom":7}]}`
+	var inv Invoice
+	if err := json.Unmarshal([]byte(in), &inv); err != nil {
+		t.Fatal(err)
+	}
+	inv.PONumber = "PO-1" // a changed value
+	b, err := json.Marshal(inv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out["customField"] != "kept" {
+		t.Errorf("customField = %v, want kept", out["customField"])
+	}
+	if v, ok := out["salesRep"]; !ok || v != nil {
+		t.Errorf("salesRep = %v (present %v), want null", v, ok)
+	}
+	if out["poNumber"] != "PO-1" {
+		t.Errorf("poNumber = %v, want PO-1", out["poNumber"])
+	}
+	lineItem := out["invoiceLineItems"].([]interface{})[0].(map[string]interface{})
+	if lineItem["lineCustom"] != float64(7) {
+		t.Errorf("lineCustom = %v, want 7", lineItem["lineCustom"])
+	}
+	if v, ok := lineItem["taxCode"]; !ok || v != nil {
+		t.Errorf("taxCode = %v (present %v), want null", v, ok)
+	}
+}
+
+func TestForNewInvoiceCopiesExtra(t *testing.T) {
+	var li InvoiceLineItem
+	if err := json.Unmarshal([]byte(`{"id":"0il1","invoiceId":"00e1","taxCode":null,"lineCustom":"a"}`), &li); err != nil {
+		t.Fatal(err)
+	}
+	copied := li.forNewInvoice()
+	copied.Extra["lineCustom"] = json.RawMessage(`"b"`)
+	copied.nulls["other"] = true
+	if string(li.Extra["lineCustom"]) != `"a"` {
+		t.Errorf("original Extra changed to %s", li.Extra["lineCustom"])
+	}
+	if li.nulls["other"] {
+		t.Error("original nulls changed")
+	}
+	if copied.ID != "" || copied.InvoiceID != "" {
+		t.Errorf("copy kept IDs %q, %q", copied.ID, copied.InvoiceID)
+	}
+}
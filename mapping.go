@@ -22,11 +22,16 @@ const (
 	Items                             = "Items"
 	CustomerAccountsID                = "CustomerAccountsID"   // account number to bill.com id
 	CustomerAccountsName              = "CustomerAccountsName" // customer name to account number
+	ChartOfAccounts                   = "ChartOfAccounts"
+	Departments                       = "Departments"
+	Jobs                              = "Jobs"
+	Employees                         = "Employees"
 )
 
 type mapping map[string]string
 
-var availableMappings = []resourceType{Locations, Classes, Customers, Vendors, Items, CustomerAccountsID, CustomerAccountsName}
+var availableMappings = []resourceType{Locations, Classes, Customers, Vendors, Items, CustomerAccountsID, CustomerAccountsName,
+	ChartOfAccounts, Departments, Jobs, Employees}
 
 // FetchAllMappingFiles overwrites the map of {resourceID: value} stored in the bdc_mappings/{resource}.json files
 // for all active resource items or creates those files if they don't exist.
@@ -36,7 +41,7 @@ var availableMappings = []resourceType{Locations, Classes, Customers, Vendors, I
 // Every map includes an entry  "*-LastUpdated" with a timestamp of the last time the file was updated
 func (c *Client) FetchAllMappingFiles() error {
 	log.Printf("Fetching all mapping files and writing to %s/ folder.\nThis may take several moments...",
-		mappingsDir)
+		c.mappingsDir)
 	for _, resource := range availableMappings {
 		err := c.FetchMappingFile(resource)
 		if err != nil {
@@ -52,14 +57,14 @@ func (c *Client) FetchAllMappingFiles() error {
 // Inverts the result returned by the server into a mapping
 // in the form: map[CustomIdentifier]BillDotComIdentifier
 // Inactive resourceIDs within bill.com are ignored
-// Options: Locations, Classes, Customers, Vendors, Items
+// Options: Locations, Classes, Customers, Vendors, Items, ChartOfAccounts, Departments, Jobs, Employees
 func (c *Client) FetchMappingFile(resource resourceType) error {
 	now := time.Now().UTC()                                        // timestamp at the start of function execution, so no contemporaneous updates are missed in the future
 	beginningOfTime := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC) // in the bill.com world, at least
 	mInverted := make(mapping)
 	var err error
 	p := NewParameters()
-	p.AddFilter("isActive", "=", "1")
+	p.AddFilter("isActive", "=", Active)
 
 	m, err := c.fetchMap(resource, beginningOfTime, p)
 	if err != nil {
@@ -71,11 +76,11 @@ func (c *Client) FetchMappingFile(resource resourceType) error {
 		mInverted[v] = k
 	}
 
-	if _, err := os.Stat(mappingsDir); os.IsNotExist(err) {
-		os.Mkdir(mappingsDir, os.ModePerm)
+	if _, err := os.Stat(c.mappingsDir); os.IsNotExist(err) {
+		os.MkdirAll(c.mappingsDir, os.ModePerm)
 	}
 
-	err = createOrReplaceMappingFile(mInverted, resource, now)
+	err = createOrReplaceMappingFile(c.mappingsDir, mInverted, resource, now)
 	if err != nil {
 		return fmt.Errorf("Unable to write mapping file for input %v: %v", resource, err)
 	}
@@ -100,12 +105,12 @@ func (c *Client) UpdateAllMappingFiles() error {
 func (c *Client) UpdateMappingFile(resource resourceType) error {
 	mInverted := make(mapping)
 	now := time.Now().UTC() // timestamp at the start of function execution, so no contemporaneous updates are missed in the future
-	lastUpdated, err := readLastUpdatedTime(resource)
+	lastUpdated, err := readLastUpdatedTime(c.mappingsDir, resource)
 	if err != nil {
 		return fmt.Errorf("Unable to read last updated time for %v: %v", resource, err)
 	}
 	p := NewParameters()
-	p.AddFilter("isActive", "=", "1")
+	p.AddFilter("isActive", "=", Active)
 	m, err := c.fetchMap(resource, lastUpdated, p)
 	if err != nil {
 		return fmt.Errorf("Unable to get mapping: %v", err)
@@ -116,7 +121,7 @@ func (c *Client) UpdateMappingFile(resource resourceType) error {
 		mInverted[v] = k
 	}
 
-	err = updateMappingFile(mInverted, resource, now)
+	err = updateMappingFile(c.mappingsDir, mInverted, resource, now)
 	if err != nil {
 		return fmt.Errorf("Unable to update mapping file: %v", err)
 	}
@@ -152,18 +157,26 @@ func (c *Client) fetchMap(resource resourceType, t time.Time, p *Parameters) (ma
 		mapping, err = c.customerAccountIDMap(t, p)
 	case r == CustomerAccountsName:
 		mapping, err = c.customerAccountNameMap(t, p)
+	case r == ChartOfAccounts:
+		mapping, err = c.chartOfAccountMap(t, p)
+	case r == Departments:
+		mapping, err = c.departmentMap(t, p)
+	case r == Jobs:
+		mapping, err = c.jobMap(t, p)
+	case r == Employees:
+		mapping, err = c.employeeMap(t, p)
 	default:
 		return nil, fmt.Errorf("Unable to find client resource for type %v", resource)
 	}
 	return
 }
 
-// getMapping reads from a file and returns a map for a specified resource
+// getMapping reads from a file in dir and returns a map for a specified resource
 // in form: map[CustomIdentifier]BillDotComIdentifier
-func getMapping(resource resourceType) (mapping, error) {
+func getMapping(dir string, resource resourceType) (mapping, error) {
 	var m mapping
 
-	fPath := path.Join(mappingsDir, string(resource)+".json")
+	fPath := path.Join(dir, string(resource)+".json")
 	b, err := ioutil.ReadFile(fPath)
 	if err != nil {
 		return nil, fmt.Errorf("Unable to read file %v: %v. Have you run client.FetchAllMappingFiles() yet?", fPath, err)
@@ -176,8 +189,8 @@ func getMapping(resource resourceType) (mapping, error) {
 }
 
 // returns items mapping for convenience in creating invoice line items
-func getItemsMapping() (mapping, error) {
-	m, err := getMapping(Items)
+func getItemsMapping(dir string) (mapping, error) {
+	m, err := getMapping(dir, Items)
 	if err != nil {
 		return nil, fmt.Errorf("Unable to get convenience mappings for items: %v", err)
 	}
@@ -186,12 +199,12 @@ func getItemsMapping() (mapping, error) {
 
 // returns a map of resource type names to mappings for customer name, locations, and classes
 // for convenience in creating invoices
-func getInvoiceCreationMappings() (map[resourceType]mapping, error) {
+func getInvoiceCreationMappings(dir string) (map[resourceType]mapping, error) {
 	var invoiceCreationMappings = []resourceType{Locations, Classes, Customers}
 	masterMap := make(map[resourceType]mapping)
 	for _, resource := range invoiceCreationMappings {
 
-		m, err := getMapping(resource)
+		m, err := getMapping(dir, resource)
 		if err != nil {
 			return nil, fmt.Errorf("Unable to get mappings for invoices: %v", err)
 		}
@@ -201,14 +214,14 @@ func getInvoiceCreationMappings() (map[resourceType]mapping, error) {
 }
 
 // create file if it does not exist or overwrite it completely
-func createOrReplaceMappingFile(newMapping mapping, resource resourceType, time time.Time) error {
+func createOrReplaceMappingFile(dir string, newMapping mapping, resource resourceType, time time.Time) error {
 	newMapping["*-LastUpdated"] = time.Format(TimeFormat)
 	jsonBlob, err := json.MarshalIndent(newMapping, "", "  ")
 	if err != nil {
 		return fmt.Errorf("Unable to marshal json for resourceType %v: %v", resource, err)
 	}
 
-	filePath := path.Join(mappingsDir, string(resource)+".json")
+	filePath := path.Join(dir, string(resource)+".json")
 	err = ioutil.WriteFile(filePath, jsonBlob, 0666)
 	if err != nil {
 		return fmt.Errorf("Unable to write file for resourceType %v at %v: %v", resource, filePath, err)
@@ -216,8 +229,8 @@ func createOrReplaceMappingFile(newMapping mapping, resource resourceType, time
 	return nil
 }
 
-func readLastUpdatedTime(resource resourceType) (time.Time, error) {
-	m, err := getMapping(resource)
+func readLastUpdatedTime(dir string, resource resourceType) (time.Time, error) {
+	m, err := getMapping(dir, resource)
 	if err != nil {
 		return time.Time{}, err
 	}
@@ -232,10 +245,10 @@ func readLastUpdatedTime(resource resourceType) (time.Time, error) {
 	return t, nil
 }
 
-func updateMappingFile(updatedMapping mapping, resource resourceType, timestamp time.Time) error {
+func updateMappingFile(dir string, updatedMapping mapping, resource resourceType, timestamp time.Time) error {
 
 	// read legacy file
-	currentMapping, err := getMapping(resource)
+	currentMapping, err := getMapping(dir, resource)
 	if err != nil {
 		return fmt.Errorf("Unable to update mapping: %v", err)
 	}
@@ -243,7 +256,7 @@ func updateMappingFile(updatedMapping mapping, resource resourceType, timestamp
 		currentMapping[k] = v
 	}
 	// replace legacy file with legacy file plus additions and changes, plus an updated timestamp
-	err = createOrReplaceMappingFile(currentMapping, resource, timestamp)
+	err = createOrReplaceMappingFile(dir, currentMapping, resource, timestamp)
 	if err != nil {
 		return fmt.Errorf("Unable to update mapping: %v", err)
 	}
@@ -336,3 +349,53 @@ func (c *Client) customerAccountNameMap(t time.Time, p *Parameters) (mapping, er
 	}
 	return m, nil
 }
+
+func (c *Client) chartOfAccountMap(t time.Time, p *Parameters) (mapping, error) {
+	m := make(mapping)
+	resp, err := c.ChartOfAccount.Since(t, p)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get chart of accounts for mapping: %v", err)
+	}
+	for _, item := range resp {
+		m[item.ID] = item.Name
+	}
+	return m, nil
+}
+
+// Set as short name for consistency with locations and classes
+func (c *Client) departmentMap(t time.Time, p *Parameters) (mapping, error) {
+	m := make(mapping)
+	resp, err := c.Department.Since(t, p)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get departments for mapping: %v", err)
+	}
+	for _, item := range resp {
+		m[item.ID] = item.ShortName
+	}
+	return m, nil
+}
+
+// Set as short name for consistency with locations and classes
+func (c *Client) jobMap(t time.Time, p *Parameters) (mapping, error) {
+	m := make(mapping)
+	resp, err := c.Job.Since(t, p)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get jobs for mapping: %v", err)
+	}
+	for _, item := range resp {
+		m[item.ID] = item.ShortName
+	}
+	return m, nil
+}
+
+func (c *Client) employeeMap(t time.Time, p *Parameters) (mapping, error) {
+	m := make(mapping)
+	resp, err := c.Employee.Since(t, p)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get employees for mapping: %v", err)
+	}
+	for _, item := range resp {
+		m[item.ID] = item.Name
+	}
+	return m, nil
+}
@@ -0,0 +1,158 @@
+package bdc
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const entityMetadataEndpoint = "GetEntityMetadata.json"
+
+// EntityMetadata describes the fields of a Bill.com entity, eg "Invoice"
+type EntityMetadata struct {
+	Entity string
+	Fields map[string]FieldMetadata
+}
+
+// FieldMetadata describes a single field of a Bill.com entity
+type FieldMetadata struct {
+	Type       string   `json:"type"`
+	Required   bool     `json:"required"`
+	Filterable bool     `json:"filterable"`
+	Values     []string `json:"values"` // allowed values, for enum fields only
+}
+
+type entityMetadataResponse map[string]struct {
+	Fields map[string]FieldMetadata `json:"fields"`
+}
+
+var filterOperators = map[string]bool{
+	"=": true, "<": true, ">": true, "!=": true, "<=": true, ">=": true, "in": true, "nin": true,
+}
+
+// Metadata returns the field names, required flags, enum values and filterable fields of an entity, eg "Invoice".
+// The result is cached for the life of the client.
+// Once an entity's metadata is cached, the client validates parameters supplied to that resource's All()
+// and entities supplied to its Create() and Update() locally, before any request is sent
+func (c *Client) Metadata(entity string) (EntityMetadata, error) {
+	c.mu.Lock()
+	m, ok := c.metadata[entity]
+	c.mu.Unlock()
+	if ok {
+		return m, nil
+	}
+
+	var resp entityMetadataResponse
+	err := c.call(entityMetadataEndpoint, map[string]interface{}{"entity": []string{entity}}, &resp)
+	if err != nil {
+		return EntityMetadata{}, fmt.Errorf("Unable to get metadata for %s: %v", entity, err)
+	}
+	fields, ok := resp[entity]
+	if !ok {
+		return EntityMetadata{}, fmt.Errorf("Unable to get metadata for %s: not in response", entity)
+	}
+	m = EntityMetadata{Entity: entity, Fields: fields.Fields}
+
+	c.mu.Lock()
+	if c.metadata == nil {
+		c.metadata = make(map[string]EntityMetadata)
+	}
+	c.metadata[entity] = m
+	c.mu.Unlock()
+	return m, nil
+}
+
+// cachedMetadata returns the metadata for the entity at suffix (eg "Invoice.json") if it has already been fetched
+func (c *Client) cachedMetadata(suffix string) (EntityMetadata, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m, ok := c.metadata[strings.TrimSuffix(suffix, ".json")]
+	return m, ok
+}
+
+// ValidateParameters checks that every filter is on a filterable field with a valid operator and,
+// for enum fields, valid values; and that every sort is on a known field
+func (m EntityMetadata) ValidateParameters(p *Parameters) error {
+	if p == nil {
+		return nil
+	}
+	var errSlice []string
+	for _, f := range p.filters {
+		field, ok := m.Fields[f.field]
+		switch {
+		case !ok:
+			errSlice = append(errSlice, fmt.Sprintf("%s has no field %q", m.Entity, f.field))
+		case !field.Filterable:
+			errSlice = append(errSlice, fmt.Sprintf("%s field %q cannot be filtered", m.Entity, f.field))
+		case !filterOperators[f.op]:
+			errSlice = append(errSlice, fmt.Sprintf("invalid operator %q on %s field %q", f.op, m.Entity, f.field))
+		default:
+			values := []interface{}{f.value}
+			if s, ok := f.value.(string); ok && (f.op == "in" || f.op == "nin") {
+				values = nil
+				for _, v := range strings.Split(s, ",") {
+					values = append(values, strings.TrimSpace(v))
+				}
+			}
+			for _, v := range values {
+				if err := field.validateValue(v); err != nil {
+					errSlice = append(errSlice, fmt.Sprintf("invalid filter on %s field %q: %v", m.Entity, f.field, err))
+				}
+			}
+		}
+	}
+	for _, s := range p.sorts {
+		if _, ok := m.Fields[s.field]; !ok {
+			errSlice = append(errSlice, fmt.Sprintf("%s has no field %q to sort by", m.Entity, s.field))
+		}
+	}
+	return handleErrSlice(errSlice)
+}
+
+// Validate checks that an entity (eg an Invoice) has a value for every required field
+// and a valid value for every enum field
+func (m EntityMetadata) Validate(entity interface{}) error {
+	b, err := json.Marshal(entity)
+	if err != nil {
+		return fmt.Errorf("Unable to encode entity: %v", err)
+	}
+	var values map[string]interface{}
+	err = json.Unmarshal(b, &values)
+	if err != nil {
+		return fmt.Errorf("Unable to decode entity: %v", err)
+	}
+	var errSlice []string
+	for name, field := range m.Fields {
+		v, ok := values[name]
+		if field.Required && !readOnlyFields[name] && (!ok || v == nil || v == "") {
+			errSlice = append(errSlice, fmt.Sprintf("%s field %q is required", m.Entity, name))
+			continue
+		}
+		if ok && v != nil && v != "" {
+			if err := field.validateValue(v); err != nil {
+				errSlice = append(errSlice, fmt.Sprintf("%s field %q: %v", m.Entity, name, err))
+			}
+		}
+	}
+	sort.Strings(errSlice)
+	return handleErrSlice(errSlice)
+}
+
+// check a value against the allowed values of an enum field
+func (f FieldMetadata) validateValue(v interface{}) error {
+	if len(f.Values) == 0 {
+		return nil
+	}
+	b, err := json.Marshal(v) // as sent to Bill.com, eg Active as "1"
+	if err != nil {
+		return fmt.Errorf("Unable to encode value %v: %v", v, err)
+	}
+	s := strings.Trim(string(b), `"`)
+	for _, allowed := range f.Values {
+		if s == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("value %q is not one of %v", s, f.Values)
+}
@@ -0,0 +1,111 @@
+package bdc
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	mfaChallengeEndpoint    = "MFAChallenge.json"
+	mfaAuthenticateEndpoint = "MFAAuthenticate.json"
+)
+
+// MFATokenFunc is called with the challenge ID when Bill.com requires multi-factor authentication,
+// and must return the token the user received (eg by text message)
+type MFATokenFunc func(challengeID string) (string, error)
+
+type mfaChallenge struct {
+	ChallengeID string `json:"challengeId"`
+}
+
+type mfaAuthentication struct {
+	MFAID string `json:"mfaId"`
+}
+
+// SetMFAHandler registers a function that supplies MFA tokens,
+// so that sensitive operations (eg paying bills) authenticate automatically when required.
+// machineName labels the device in Bill.com, as in c.AuthenticateMFA().
+// Not needed if the config file already holds a remembered device
+func (c *Client) SetMFAHandler(machineName string, getToken MFATokenFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mfaMachineName, c.mfaHandler = machineName, getToken
+}
+
+// mfaDevice returns the MFA ID and device ID that the session is authenticated with, if any
+func (c *Client) mfaDevice() (id, deviceID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.mfaID, c.mfaDeviceID
+}
+
+// AuthenticateMFA requests an MFA challenge, submits the token returned by getToken,
+// and remembers this device so that later sessions (eg headless jobs) can skip the challenge.
+// The remembered device is saved in the config file.
+// machineName labels the device in Bill.com, eg "nightly-payments-job"
+func (c *Client) AuthenticateMFA(machineName string, getToken MFATokenFunc) error {
+	var challenge mfaChallenge
+	err := c.call(mfaChallengeEndpoint, map[string]interface{}{"useBackup": false}, &challenge)
+	if err != nil {
+		return fmt.Errorf("Unable to request MFA challenge: %v", err)
+	}
+	token, err := getToken(challenge.ChallengeID)
+	if err != nil {
+		return fmt.Errorf("Unable to get MFA token: %v", err)
+	}
+
+	_, deviceID := c.mfaDevice()
+	if deviceID == "" {
+		deviceID, err = newDeviceID()
+		if err != nil {
+			return fmt.Errorf("Unable to authenticate MFA: %v", err)
+		}
+	}
+	values := map[string]interface{}{
+		"challengeId": challenge.ChallengeID,
+		"token":       token,
+		"deviceId":    deviceID,
+		"machineName": machineName,
+		"rememberMe":  true,
+	}
+	var auth mfaAuthentication
+	err = c.call(mfaAuthenticateEndpoint, values, &auth)
+	if err != nil {
+		return fmt.Errorf("Unable to authenticate MFA: %v", err)
+	}
+	c.mu.Lock()
+	c.mfaID, c.mfaDeviceID = auth.MFAID, deviceID
+	c.mu.Unlock()
+
+	err = saveMFAConfig(auth.MFAID, deviceID)
+	if err != nil {
+		return fmt.Errorf("MFA authenticated, but unable to remember device: %v", err)
+	}
+	return nil
+}
+
+// requireMFA ensures that the session is authenticated with MFA before a sensitive operation,
+// using the registered MFA handler if necessary
+func (c *Client) requireMFA() error {
+	if id, _ := c.mfaDevice(); id != "" || c.dryRunPlan() != nil {
+		return nil
+	}
+	c.mu.Lock()
+	machineName, handler := c.mfaMachineName, c.mfaHandler
+	c.mu.Unlock()
+	if handler == nil {
+		return fmt.Errorf("Operation requires multi-factor authentication: call c.AuthenticateMFA() or c.SetMFAHandler() first")
+	}
+	return c.AuthenticateMFA(machineName, handler)
+}
+
+// a random identifier for this device
+func newDeviceID() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("Unable to generate device ID: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,128 @@
+package bdc
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an exact amount of dollars, stored as a whole number of cents.
+// It is encoded to and decoded from JSON as a number with two decimal places (eg 12.34), as Bill.com expects
+type Money int64
+
+// Dollars converts a float64 number of dollars to Money, rounding to the nearest cent
+func Dollars(d float64) Money {
+	return Money(math.Round(d * 100))
+}
+
+// ParseMoney parses a decimal number of dollars, eg "1234.50" or "-3", without passing through float64.
+// Amounts with more than two decimal places are rounded half away from zero to the nearest cent
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if strings.ContainsAny(s, "eE") { // exponent notation
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("Unable to parse %q as money: %v", s, err)
+		}
+		if math.Abs(f) >= math.MaxInt64/100 {
+			return 0, fmt.Errorf("Unable to parse %q as money: out of range", s)
+		}
+		return Dollars(f), nil
+	}
+	digits := s
+	neg := strings.HasPrefix(s, "-")
+	if neg || strings.HasPrefix(s, "+") { // at most one sign
+		digits = s[1:]
+	}
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		return 0, fmt.Errorf("Unable to parse %q as money: more than one sign", s)
+	}
+	parts := strings.SplitN(digits, ".", 2)
+	if parts[0] == "" && (len(parts) == 1 || parts[1] == "") {
+		return 0, fmt.Errorf("Unable to parse %q as money: no digits", s)
+	}
+	var whole int64
+	if parts[0] != "" {
+		var err error
+		whole, err = strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("Unable to parse %q as money: %v", s, err)
+		}
+		if whole >= math.MaxInt64/100 { // leaves room for the cents
+			return 0, fmt.Errorf("Unable to parse %q as money: out of range", s)
+		}
+	}
+	var frac string
+	if len(parts) == 2 {
+		frac = parts[1]
+		if strings.Trim(frac, "0123456789") != "" {
+			return 0, fmt.Errorf("Unable to parse %q as money: invalid decimal places", s)
+		}
+	}
+	frac += "000"
+	cents := whole*100 + int64(frac[0]-'0')*10 + int64(frac[1]-'0')
+	if frac[2] >= '5' {
+		cents++
+	}
+	if neg {
+		cents = -cents
+	}
+	return Money(cents), nil
+}
+
+// Dollars returns m as a float64 number of dollars, eg for display or arithmetic outside this package
+func (m Money) Dollars() float64 {
+	return float64(m) / 100
+}
+
+// String formats m with two decimal places, eg "-12.30"
+func (m Money) String() string {
+	sign := ""
+	cents := int64(m)
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// MarshalJSON encodes m as a number with two decimal places
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON decodes a number or numeric string; null decodes to zero
+func (m *Money) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "null" || s == "" {
+		*m = 0
+		return nil
+	}
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Split divides m into n parts that differ by at most one cent and sum exactly to m.
+// Any remaining cents are assigned to the first parts, eg 100.00 split 3 ways is 33.34, 33.33, 33.33
+func (m Money) Split(n int) []Money {
+	if n <= 0 {
+		return nil
+	}
+	share := int64(m) / int64(n)
+	remainder := int64(m) % int64(n)
+	parts := make([]Money, n)
+	for i := range parts {
+		parts[i] = Money(share)
+		if remainder > 0 && int64(i) < remainder {
+			parts[i]++
+		} else if remainder < 0 && int64(i) < -remainder {
+			parts[i]--
+		}
+	}
+	return parts
+}
@@ -0,0 +1,133 @@
+package bdc
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Money
+		wantErr bool
+	}{
+		{"1234.50", 123450, false},
+		{"-3", -300, false},
+		{" 12.3 ", 1230, false},
+		{".5", 50, false},
+		{"+7.01", 701, false},
+		{"0.10", 10, false},
+		{"1.005", 101, false},   // half rounds up
+		{"1.0049", 100, false},  // below half rounds down
+		{"-1.005", -101, false}, // half rounds away from zero
+		{"-0.004", 0, false},
+		{"0.1 ", 10, false},
+		{"19.99999", 2000, false},
+		{"1e2", 10000, false},
+		{"", 0, true},
+		{"-", 0, true},
+		{".", 0, true},
+		{"abc", 0, true},
+		{"1.2x", 0, true},
+		{"1,000.00", 0, true},
+		{"--5", 0, true},
+		{"+-5", 0, true},
+		{"-+5", 0, true},
+		{"++5", 0, true},
+		{"9223372036854775807", 0, true},
+		{"92233720368547758", 0, true},
+		{"-92233720368547758.07", 0, true},
+		{"92233720368547757.99", 9223372036854775799, false},
+		{"1e300", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseMoney(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseMoney(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMoneySplit(t *testing.T) {
+	tests := []struct {
+		m    Money
+		n    int
+		want []Money
+	}{
+		{10000, 3, []Money{3334, 3333, 3333}},
+		{10001, 3, []Money{3334, 3334, 3333}},
+		{10000, 4, []Money{2500, 2500, 2500, 2500}},
+		{-10000, 3, []Money{-3334, -3333, -3333}},
+		{2, 3, []Money{1, 1, 0}},
+		{0, 2, []Money{0, 0}},
+		{500, 1, []Money{500}},
+		{500, 0, nil},
+	}
+	for _, tt := range tests {
+		got := tt.m.Split(tt.n)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Money(%d).Split(%d) = %v, want %v", tt.m, tt.n, got, tt.want)
+		}
+		var sum Money
+		for _, part := range got {
+			sum += part
+		}
+		if tt.n > 0 && sum != tt.m {
+			t.Errorf("Money(%d).Split(%d) sums to %d", tt.m, tt.n, sum)
+		}
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	tests := []struct {
+		m    Money
+		json string
+	}{
+		{123450, "1234.50"},
+		{-1230, "-12.30"},
+		{5, "0.05"},
+		{-5, "-0.05"},
+		{0, "0.00"},
+	}
+	for _, tt := range tests {
+		b, err := json.Marshal(tt.m)
+		if err != nil {
+			t.Fatalf("Marshal(%d): %v", tt.m, err)
+		}
+		if string(b) != tt.json {
+			t.Errorf("Marshal(%d) = %s, want %s", tt.m, b, tt.json)
+		}
+		var back Money
+		if err := json.Unmarshal(b, &back); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", b, err)
+		}
+		if back != tt.m {
+			t.Errorf("Unmarshal(%s) = %d, want %d", b, back, tt.m)
+		}
+	}
+
+	decodes := []struct {
+		in   string
+		want Money
+	}{
+		{`"12.3"`, 1230},
+		{`12.345`, 1235},
+		{`null`, 0},
+		{`""`, 0},
+	}
+	for _, tt := range decodes {
+		var got Money
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+			t.Errorf("Unmarshal(%s): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Unmarshal(%s) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
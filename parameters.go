@@ -38,12 +38,19 @@ func (p *Parameters) AddSort(field string, asc int) {
 	p.sorts = append(p.sorts, sortOption{field, asc})
 }
 
-func encodeParameters(params []*Parameters) (filters, sort []map[string]interface{}) {
+// encodeParameters converts the parameters supplied to the resource at suffix into filters and sorts.
+// If the client has cached the resource's metadata (see c.Metadata), the parameters are validated against it first
+func (c *Client) encodeParameters(suffix string, params []*Parameters) (filters, sort []map[string]interface{}, err error) {
 	switch p := len(params); {
 	case p > 1:
 		fmt.Println("Can supply at most one parameter object")
-		return nil, nil
+		return nil, nil, nil
 	case p == 1:
+		if m, ok := c.cachedMetadata(suffix); ok {
+			if err := m.ValidateParameters(params[0]); err != nil {
+				return nil, nil, fmt.Errorf("Invalid parameters: %v", err)
+			}
+		}
 		var filters, sorts []map[string]interface{}
 		params := params[0]
 		for _, filter := range params.filters {
@@ -52,8 +59,8 @@ func encodeParameters(params []*Parameters) (filters, sort []map[string]interfac
 		for _, sort := range params.sorts {
 			sorts = append(sorts, map[string]interface{}{"field": sort.field, "asc": sort.asc})
 		}
-		return filters, sorts
+		return filters, sorts, nil
 	default:
-		return nil, nil
+		return nil, nil, nil
 	}
 }
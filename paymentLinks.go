@@ -0,0 +1,161 @@
+package bdc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maximum number of IDs in a single "in" filter, to keep requests to a reasonable size
+const inFilterMax = 100
+
+// listIn calls list once per batch of up to inFilterMax values, filtering field "in" the batch
+func listIn(field string, values []string, list func(p *Parameters) error) error {
+	values = unique(values)
+	for start := 0; start < len(values); start += inFilterMax {
+		end := start + inFilterMax
+		if end > len(values) {
+			end = len(values)
+		}
+		p := NewParameters()
+		p.AddFilter(field, "in", strings.Join(values[start:end], ","))
+		if err := list(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unique returns the non-empty values in their original order, without duplicates
+func unique(values []string) []string {
+	seen := make(map[string]bool)
+	var ret []string
+	for _, v := range values {
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		ret = append(ret, v)
+	}
+	return ret
+}
+
+// byID returns the invoices with the IDs provided, fetched in batches
+func (r invoiceResource) byID(ids []string) ([]Invoice, error) {
+	var invoices []Invoice
+	err := listIn("id", ids, func(p *Parameters) error {
+		batch, err := r.All(p)
+		invoices = append(invoices, batch...)
+		return err
+	})
+	return invoices, err
+}
+
+// Payments returns the payments received that were applied to an invoice
+func (r invoiceResource) Payments(id string) ([]PaymentReceived, error) {
+	payments, err := r.PaymentsFor([]string{id})
+	if err != nil {
+		return nil, err
+	}
+	return payments[id], nil
+}
+
+// PaymentsFor returns the payments received that were applied to each of the invoices provided, by invoice ID.
+// Fetches the invoices and their customers' payments in batches rather than one request per invoice.
+// Bill.com cannot filter payments by the invoices they were applied to, so every payment of those customers
+// updated since the earliest invoice was created is fetched and matched locally;
+// for customers with a long payment history, pass invoices of similar age together
+func (r invoiceResource) PaymentsFor(ids []string) (map[string][]PaymentReceived, error) {
+	invoices, err := r.byID(ids)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get payments for invoices: %v", err)
+	}
+	wanted := make(map[string]bool)
+	var customers []string
+	var earliest time.Time
+	for idx, inv := range invoices {
+		wanted[inv.ID] = true
+		customers = append(customers, inv.CustomerID)
+		if idx == 0 || inv.CreatedTime.Before(earliest) { // zero if any creation time is unknown, so nothing is missed
+			earliest = inv.CreatedTime.Time
+		}
+	}
+	ret := make(map[string][]PaymentReceived)
+	err = listIn("customerId", customers, func(p *Parameters) error {
+		// a payment is updated when it is applied, so one applied to these invoices cannot predate them
+		if !earliest.IsZero() {
+			p.AddFilter("updatedTime", ">=", earliest.Format(TimeFormat))
+		}
+		payments, err := r.client.PaymentReceived.All(p)
+		for _, payment := range payments {
+			applied := make(map[string]bool)
+			for _, pay := range payment.InvoicePays {
+				if wanted[pay.InvoiceID] && !applied[pay.InvoiceID] {
+					applied[pay.InvoiceID] = true
+					ret[pay.InvoiceID] = append(ret[pay.InvoiceID], payment)
+				}
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get payments for invoices: %v", err)
+	}
+	return ret, nil
+}
+
+// Invoices returns the invoices that a payment received was applied to
+func (r paymentReceivedResource) Invoices(id string) ([]Invoice, error) {
+	payment, err := r.Get(id)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get invoices for payment %s: %v", id, err)
+	}
+	var ids []string
+	for _, pay := range payment.InvoicePays {
+		ids = append(ids, pay.InvoiceID)
+	}
+	invoices, err := r.client.Invoice.byID(ids)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get invoices for payment %s: %v", id, err)
+	}
+	return invoices, nil
+}
+
+// Payments returns the payments made against a bill
+func (r billResource) Payments(id string) ([]PaymentMade, error) {
+	payments, err := r.PaymentsFor([]string{id})
+	if err != nil {
+		return nil, err
+	}
+	return payments[id], nil
+}
+
+// PaymentsFor returns the payments made against each of the bills provided, by bill ID.
+// Fetches the payments in batches rather than one request per bill
+func (r billResource) PaymentsFor(ids []string) (map[string][]PaymentMade, error) {
+	ret := make(map[string][]PaymentMade)
+	err := listIn("billId", ids, func(p *Parameters) error {
+		payments, err := r.client.PaymentMade.All(p)
+		for _, payment := range payments {
+			ret[payment.BillID] = append(ret[payment.BillID], payment)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get payments for bills: %v", err)
+	}
+	return ret, nil
+}
+
+// Bill returns the bill that a payment made was applied to
+func (r paymentMadeResource) Bill(id string) (Bill, error) {
+	payment, err := r.Get(id)
+	if err != nil {
+		return Bill{}, fmt.Errorf("Unable to get bill for payment %s: %v", id, err)
+	}
+	bill, err := r.client.Bill.Get(payment.BillID)
+	if err != nil {
+		return Bill{}, fmt.Errorf("Unable to get bill for payment %s: %v", id, err)
+	}
+	return bill, nil
+}
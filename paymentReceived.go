@@ -10,34 +10,35 @@ type paymentResponse struct {
 	Data []PaymentReceived `json:"response_data"`
 }
 
+type paymentResp struct {
+	Data PaymentReceived `json:"response_data"`
+}
+
 // PaymentReceived in Bill.com, associated with an invoice
 type PaymentReceived struct {
-	Entity      string `json:"entity"`
-	ID          string `json:"id"`
-	CreatedTime string `json:"createdTime"`
-	UpdatedTime string `json:"updatedTime"`
-	CustomerID  string `json:"customerId"`
-	// 0. Paid; 1. Void; 2. Scheduled; 3. Canceled
-	Status             string `json:"status"`
-	PaymentDate        string `json:"paymentDate"`
-	DepositToAccountID string `json:"depositToAccountId"`
-	IsOnline           bool   `json:"isOnline"`
-	// 0. Cash; 1. Check; 2. CreditCard; 3. ACH; 4. PayPal; 5. Other
-	PaymentType   string  `json:"paymentType"`
-	Amount        float64 `json:"amount"`
-	Description   string  `json:"description"`
-	RefNumber     string  `json:"refNumber"`
-	ConvFeeAmount float64 `json:"convFeeAmount"`
-	InvoicePays   []struct {
-		Entity      string  `json:"entity"`
-		ID          string  `json:"id"`
-		InvoiceID   string  `json:"invoiceId"`
-		Amount      float64 `json:"amount"`
-		Description string  `json:"description"`
-		CreatedTime string  `json:"createdTime"`
-		UpdatedTime string  `json:"updatedTime"`
-		// 0. Paid; 1. Void; 2. Scheduled; 3. Canceled; 4. Initiated
-		Status string `json:"status"`
+	Entity             string        `json:"entity"`
+	ID                 string        `json:"id"`
+	CreatedTime        Timestamp     `json:"createdTime"`
+	UpdatedTime        Timestamp     `json:"updatedTime"`
+	CustomerID         string        `json:"customerId"`
+	Status             PaymentStatus `json:"status"`
+	PaymentDate        Date          `json:"paymentDate"`
+	DepositToAccountID string        `json:"depositToAccountId"`
+	IsOnline           bool          `json:"isOnline"`
+	PaymentType        PaymentType   `json:"paymentType"`
+	Amount             Money         `json:"amount"`
+	Description        string        `json:"description"`
+	RefNumber          string        `json:"refNumber"`
+	ConvFeeAmount      Money         `json:"convFeeAmount"`
+	InvoicePays        []struct {
+		Entity      string        `json:"entity"`
+		ID          string        `json:"id"`
+		InvoiceID   string        `json:"invoiceId"`
+		Amount      Money         `json:"amount"`
+		Description string        `json:"description"`
+		CreatedTime Timestamp     `json:"createdTime"`
+		UpdatedTime Timestamp     `json:"updatedTime"`
+		Status      PaymentStatus `json:"status"`
 	} `json:"invoicePays"`
 }
 
@@ -45,6 +46,19 @@ type paymentReceivedResource struct {
 	resourceFields
 }
 
+// Get returns a single PaymentReceived object
+func (r paymentReceivedResource) Get(id string) (PaymentReceived, error) {
+	payment, err := r.client.getOne(r.suffix, id)
+	if err != nil {
+		return PaymentReceived{}, fmt.Errorf("Unable to get payment received id %v: %v", id, err)
+	}
+	var goodResp paymentResp
+	if err := json.Unmarshal(payment, &goodResp); err != nil {
+		return PaymentReceived{}, fmt.Errorf("Unable to read payment received id %v: %v", id, err)
+	}
+	return goodResp.Data, nil
+}
+
 // All bills
 func (r paymentReceivedResource) All(parameters ...*Parameters) ([]PaymentReceived, error) {
 	results := r.client.getAll(r.suffix, parameters)
@@ -56,7 +70,10 @@ func (r paymentReceivedResource) All(parameters ...*Parameters) ([]PaymentReceiv
 			errSlice = append(errSlice, fmt.Sprintf("Error on page %v: %v", resp.page, resp.err))
 		} else {
 			var goodResp paymentResponse
-			json.Unmarshal(resp.result, &goodResp)
+			if err := json.Unmarshal(resp.result, &goodResp); err != nil {
+				errSlice = append(errSlice, fmt.Sprintf("Unable to read page %v: %v", resp.page, err))
+				continue
+			}
 			retList = append(retList, goodResp.Data...)
 		}
 	}
@@ -94,3 +111,54 @@ func (r paymentReceivedResource) SinceFileTime(filePath string, params *Paramete
 	}
 	return payments, nil
 }
+
+const recordPaymentEndpoint = "RecordARPayment.json"
+
+// Record a payment received outside of Bill.com (eg by check or wire) and apply it to a customer's invoices.
+// paymentType must be one of the PaymentType options (eg PaymentCheck).
+// Each allocation may not exceed the amount due on its invoice, and all allocations together may not exceed amount.
+// Returns the payment as stored by Bill.com
+func (r paymentReceivedResource) Record(customerID string, amount Money, paymentType PaymentType, paymentDate Date, refNumber string,
+	allocations []InvoiceAllocation) (PaymentReceived, error) {
+	if amount <= 0 {
+		return PaymentReceived{}, fmt.Errorf("Unable to record payment from customer %s: amount must be greater than 0", customerID)
+	}
+	_, err := r.client.validateInvoiceAllocations(customerID, amount, allocations)
+	if err != nil {
+		return PaymentReceived{}, fmt.Errorf("Unable to record payment from customer %s: %v", customerID, err)
+	}
+	if !paymentType.valid() {
+		return PaymentReceived{}, fmt.Errorf("Unable to record payment from customer %s: unknown payment type %q", customerID, paymentType)
+	}
+	if paymentDate.IsZero() {
+		return PaymentReceived{}, fmt.Errorf("Unable to record payment from customer %s: must provide a payment date", customerID)
+	}
+
+	var invoicePays []map[string]interface{}
+	for _, a := range allocations {
+		invoicePays = append(invoicePays, map[string]interface{}{
+			"entity":    "InvoicePay",
+			"invoiceId": a.InvoiceID,
+			"amount":    a.Amount,
+		})
+	}
+	values := map[string]interface{}{
+		"obj": map[string]interface{}{
+			"entity":      "ReceivedPay",
+			"customerId":  customerID,
+			"paymentDate": paymentDate,
+			"paymentType": paymentType,
+			"amount":      amount,
+			"refNumber":   refNumber,
+			"invoicePays": invoicePays,
+		},
+	}
+	var payment PaymentReceived
+	err = r.client.mutate(recordPaymentEndpoint, values, &payment)
+	if err != nil {
+		return PaymentReceived{}, fmt.Errorf("Unable to record payment from customer %s in amount %v: %v", customerID, amount, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Recorded payment %s (ref %s) from customer %s in amount %v applied to %v",
+		payment.ID, refNumber, customerID, amount, allocations))
+	return payment, nil
+}
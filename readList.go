@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/url"
 	"sort"
 	"strings"
 	"sync"
@@ -21,11 +20,9 @@ func encodeReadListData(c *Client, start int, max int, filters, sorts []map[stri
 	values["filters"] = filters
 	values["sort"] = sorts
 	// encode payload as URL
-	data := url.Values{}
+	data := c.sessionValues()
 	jsonValues, _ := json.Marshal(values)
 	data.Set("data", string(jsonValues))
-	data.Set("sessionId", c.sessionID)
-	data.Set("devKey", c.devKey)
 
 	body := strings.NewReader(data.Encode())
 	return body
@@ -34,7 +31,7 @@ func encodeReadListData(c *Client, start int, max int, filters, sorts []map[stri
 // Get up to pageMax records from an endpoint starting at record number "start" with optional filters
 func (c *Client) getPage(start int, max int, endpoint string, filters, sorts []map[string]interface{}) resultError {
 	body := encodeReadListData(c, start, max, filters, sorts)
-	resp, err := makeRequest(endpoint, body)
+	resp, err := c.send(endpoint, body)
 	if err != nil {
 		return resultError{err: err}
 	}
@@ -113,8 +110,14 @@ func (c *Client) fetchRoutine(pages <-chan int, results chan<- resultError, endp
 // deploys asynchronous countRoutines to count the number of pages available,
 // then deploys asynchronous fetchRoutines to fetch all the items from all the available pages
 func (c *Client) getAll(suffix string, parameters []*Parameters) (result []resultError) {
+	if c.isClosed() {
+		return []resultError{{err: ErrClientClosed}}
+	}
 	endpoint := "List/" + suffix
-	filters, sorts := encodeParameters(parameters)
+	filters, sorts, err := c.encodeParameters(suffix, parameters)
+	if err != nil {
+		return []resultError{{err: err}}
+	}
 	numPages := c.countPages(endpoint, filters, sorts)
 	time.Sleep(500 * time.Millisecond) // sometimes a trailing goroutine exceeds the allowable concurrent threads
 
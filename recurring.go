@@ -0,0 +1,76 @@
+package bdc
+
+import (
+	"fmt"
+	"time"
+)
+
+// RecurrencePeriod is the unit of time between occurrences of a recurring invoice or bill
+type RecurrencePeriod string
+
+// Recurrence period options
+const (
+	PeriodDay   RecurrencePeriod = "0"
+	PeriodWeek  RecurrencePeriod = "1"
+	PeriodMonth RecurrencePeriod = "2"
+	PeriodYear  RecurrencePeriod = "3"
+)
+
+// Schedule of a recurring invoice or bill in Bill.com
+type Schedule struct {
+	TimePeriod RecurrencePeriod `json:"timePeriod"`
+	// number of periods between occurrences, eg 2 with PeriodWeek recurs every other week
+	FrequencyPerTimePeriod int  `json:"frequencyPerTimePeriod"`
+	NextDueDate            Date `json:"nextDueDate"`
+	EndDate                Date `json:"endDate"` // zero if the schedule never ends
+	DaysInAdvance          int  `json:"daysInAdvance"`
+}
+
+// DueDates returns every due date on the schedule from NextDueDate through the earlier of EndDate and until,
+// eg for forecasting
+func (s Schedule) DueDates(until time.Time) ([]Date, error) {
+	if s.NextDueDate.IsZero() {
+		return nil, fmt.Errorf("Schedule has no next due date")
+	}
+	next := s.NextDueDate.Time
+	if !s.EndDate.IsZero() && s.EndDate.Before(until) {
+		until = s.EndDate.Time
+	}
+	n := s.FrequencyPerTimePeriod
+	if n < 1 {
+		n = 1
+	}
+	var dates []Date
+	for i := 0; ; i++ {
+		var d time.Time
+		// always step from the first date so that month-end dates do not drift
+		switch s.TimePeriod {
+		case PeriodDay:
+			d = next.AddDate(0, 0, i*n)
+		case PeriodWeek:
+			d = next.AddDate(0, 0, 7*i*n)
+		case PeriodMonth:
+			d = addMonths(next, i*n)
+		case PeriodYear:
+			d = addMonths(next, 12*i*n)
+		default:
+			return nil, fmt.Errorf("Unknown time period %q", s.TimePeriod)
+		}
+		if d.After(until) {
+			return dates, nil
+		}
+		dates = append(dates, Date{d})
+	}
+}
+
+// add months to a date, clamping to the end of the month (eg Jan 31 + 1 month = Feb 28)
+// rather than overflowing into the next month as time.AddDate does
+func addMonths(t time.Time, months int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()).AddDate(0, months, 0)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
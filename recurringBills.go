@@ -0,0 +1,145 @@
+package bdc
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type recurringBillResponse struct {
+	Data []RecurringBill `json:"response_data"`
+}
+
+type recurringBillResp struct {
+	Data RecurringBill `json:"response_data"`
+}
+
+// RecurringBill in Bill.com. Bill.com creates a Bill on each due date
+type RecurringBill struct {
+	Entity      string       `json:"entity"`
+	ID          string       `json:"id"`
+	CreatedTime Timestamp    `json:"createdTime"`
+	UpdatedTime Timestamp    `json:"updatedTime"`
+	IsActive    ActiveStatus `json:"isActive"`
+	VendorID    string       `json:"vendorId"`
+	Description string       `json:"description"`
+	Schedule
+	LineItems []RecurringBillLineItem `json:"recurringBillLineItems"`
+}
+
+// RecurringBillLineItem on a Bill.com recurring bill
+type RecurringBillLineItem struct {
+	Entity           string `json:"entity"`
+	Amount           Money  `json:"amount"`
+	ChartOfAccountID string `json:"chartOfAccountId"`
+	ClassID          string `json:"actgClassId"`
+	LocationID       string `json:"locationId"`
+	Description      string `json:"description"`
+}
+
+type recurringBillResource struct {
+	resourceFields
+}
+
+// Get returns a single RecurringBill object
+func (r recurringBillResource) Get(id string) (RecurringBill, error) {
+	b, err := r.client.getOne(r.suffix, id)
+	if err != nil {
+		return RecurringBill{}, fmt.Errorf("Unable to get recurring bill id %v: %v", id, err)
+	}
+	var goodResp recurringBillResp
+	if err := json.Unmarshal(b, &goodResp); err != nil {
+		return RecurringBill{}, fmt.Errorf("Unable to read recurring bill id %v: %v", id, err)
+	}
+	return goodResp.Data, nil
+}
+
+// All recurring bills
+func (r recurringBillResource) All(parameters ...*Parameters) ([]RecurringBill, error) {
+	results := r.client.getAll(r.suffix, parameters)
+
+	var retList []RecurringBill
+	var errSlice []string
+	for _, resp := range results {
+		if resp.err != nil {
+			errSlice = append(errSlice, fmt.Sprintf("Error on page %v: %v", resp.page, resp.err))
+		} else {
+			var goodResp recurringBillResponse
+			if err := json.Unmarshal(resp.result, &goodResp); err != nil {
+				errSlice = append(errSlice, fmt.Sprintf("Unable to read page %v: %v", resp.page, err))
+				continue
+			}
+			retList = append(retList, goodResp.Data...)
+		}
+	}
+	err := handleErrSlice(errSlice)
+
+	return retList, err
+}
+
+// Since returns all recurring bills updated since the time provided.
+// If no additional params to provide, must pass nil explicitly
+func (r recurringBillResource) Since(t time.Time, p *Parameters) ([]RecurringBill, error) {
+	if p == nil {
+		p = NewParameters()
+	}
+	p.AddFilter("updatedTime", ">", t.Format(TimeFormat))
+	results, err := r.client.RecurringBill.All(p)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get all recurring bills updated since %s: %v", t, err)
+	}
+	return results, nil
+}
+
+// Create a recurring bill.
+// Returns the new recurring bill as stored by Bill.com
+func (r recurringBillResource) Create(recurring RecurringBill) (RecurringBill, error) {
+	if recurring.VendorID == "" {
+		return RecurringBill{}, fmt.Errorf("Unable to create recurring bill: must provide vendor ID")
+	}
+	if recurring.NextDueDate.IsZero() {
+		return RecurringBill{}, fmt.Errorf("Unable to create recurring bill: must provide next due date")
+	}
+	recurring.Entity = "RecurringBill"
+	var created RecurringBill
+	err := r.client.createEntity(r.suffix, recurring, &created)
+	if err != nil {
+		if unreadable, ok := err.(*UnreadableResultError); ok {
+			return RecurringBill{}, unreadable
+		}
+		return RecurringBill{}, fmt.Errorf("Unable to create recurring bill for vendor %s: %v", recurring.VendorID, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Created recurring bill %s for vendor %s, next due %s", created.ID, created.VendorID, created.NextDueDate))
+	return created, nil
+}
+
+// Update one recurring bill.
+// Supply the recurring bill ID and only the fields to change, eg bdc.Set("endDate", "2020-12-31");
+// all other fields will be preserved. Zero and empty values are applied as is.
+// To guard against overwriting someone else's changes, include bdc.IfUnchanged(base).
+// Returns the updated recurring bill as stored by Bill.com
+func (r recurringBillResource) Update(id string, fields ...Field) (RecurringBill, error) {
+	var updated RecurringBill
+	changes, err := r.client.patchEntity(r.suffix, id, fields, &updated)
+	if err != nil {
+		if conflict, ok := err.(*ConflictError); ok {
+			return RecurringBill{}, conflict
+		}
+		if unreadable, ok := err.(*UnreadableResultError); ok {
+			return RecurringBill{}, unreadable
+		}
+		return RecurringBill{}, fmt.Errorf("Unable to update recurring bill %v: %v", id, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Updated recurring bill %s: %s", updated.ID, changes))
+	return updated, nil
+}
+
+// Deactivate one recurring bill, stopping any future occurrences
+func (r recurringBillResource) Deactivate(id string) error {
+	err := r.client.deactivateEntity(r.suffix, id)
+	if err != nil {
+		return fmt.Errorf("Unable to deactivate recurring bill %v: %v", id, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Deactivated recurring bill %s", id))
+	return nil
+}
@@ -0,0 +1,147 @@
+package bdc
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type recurringInvoiceResponse struct {
+	Data []RecurringInvoice `json:"response_data"`
+}
+
+type recurringInvoiceResp struct {
+	Data RecurringInvoice `json:"response_data"`
+}
+
+// RecurringInvoice in Bill.com, eg a monthly retainer. Bill.com creates an Invoice on each due date
+type RecurringInvoice struct {
+	Entity      string       `json:"entity"`
+	ID          string       `json:"id"`
+	CreatedTime Timestamp    `json:"createdTime"`
+	UpdatedTime Timestamp    `json:"updatedTime"`
+	IsActive    ActiveStatus `json:"isActive"`
+	CustomerID  string       `json:"customerId"`
+	Description string       `json:"description"`
+	Schedule
+	LineItems []RecurringInvoiceLineItem `json:"recurringInvoiceLineItems"`
+}
+
+// RecurringInvoiceLineItem on a Bill.com recurring invoice
+type RecurringInvoiceLineItem struct {
+	Entity      string `json:"entity"`
+	ItemID      string `json:"itemId"`
+	Quantity    int    `json:"quantity"`
+	Amount      Money  `json:"amount"`
+	Price       Money  `json:"price"`
+	ClassID     string `json:"actgClassId"`
+	LocationID  string `json:"locationId"`
+	Description string `json:"description"`
+}
+
+type recurringInvoiceResource struct {
+	resourceFields
+}
+
+// Get returns a single RecurringInvoice object
+func (r recurringInvoiceResource) Get(id string) (RecurringInvoice, error) {
+	b, err := r.client.getOne(r.suffix, id)
+	if err != nil {
+		return RecurringInvoice{}, fmt.Errorf("Unable to get recurring invoice id %v: %v", id, err)
+	}
+	var goodResp recurringInvoiceResp
+	if err := json.Unmarshal(b, &goodResp); err != nil {
+		return RecurringInvoice{}, fmt.Errorf("Unable to read recurring invoice id %v: %v", id, err)
+	}
+	return goodResp.Data, nil
+}
+
+// All recurring invoices
+func (r recurringInvoiceResource) All(parameters ...*Parameters) ([]RecurringInvoice, error) {
+	results := r.client.getAll(r.suffix, parameters)
+
+	var retList []RecurringInvoice
+	var errSlice []string
+	for _, resp := range results {
+		if resp.err != nil {
+			errSlice = append(errSlice, fmt.Sprintf("Error on page %v: %v", resp.page, resp.err))
+		} else {
+			var goodResp recurringInvoiceResponse
+			if err := json.Unmarshal(resp.result, &goodResp); err != nil {
+				errSlice = append(errSlice, fmt.Sprintf("Unable to read page %v: %v", resp.page, err))
+				continue
+			}
+			retList = append(retList, goodResp.Data...)
+		}
+	}
+	err := handleErrSlice(errSlice)
+
+	return retList, err
+}
+
+// Since returns all recurring invoices updated since the time provided.
+// If no additional params to provide, must pass nil explicitly
+func (r recurringInvoiceResource) Since(t time.Time, p *Parameters) ([]RecurringInvoice, error) {
+	if p == nil {
+		p = NewParameters()
+	}
+	p.AddFilter("updatedTime", ">", t.Format(TimeFormat))
+	results, err := r.client.RecurringInvoice.All(p)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get all recurring invoices updated since %s: %v", t, err)
+	}
+	return results, nil
+}
+
+// Create a recurring invoice.
+// Returns the new recurring invoice as stored by Bill.com
+func (r recurringInvoiceResource) Create(recurring RecurringInvoice) (RecurringInvoice, error) {
+	if recurring.CustomerID == "" {
+		return RecurringInvoice{}, fmt.Errorf("Unable to create recurring invoice: must provide customer ID")
+	}
+	if recurring.NextDueDate.IsZero() {
+		return RecurringInvoice{}, fmt.Errorf("Unable to create recurring invoice: must provide next due date")
+	}
+	recurring.Entity = "RecurringInvoice"
+	var created RecurringInvoice
+	err := r.client.createEntity(r.suffix, recurring, &created)
+	if err != nil {
+		if unreadable, ok := err.(*UnreadableResultError); ok {
+			return RecurringInvoice{}, unreadable
+		}
+		return RecurringInvoice{}, fmt.Errorf("Unable to create recurring invoice for customer %s: %v", recurring.CustomerID, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Created recurring invoice %s for customer %s, next due %s", created.ID, created.CustomerID, created.NextDueDate))
+	return created, nil
+}
+
+// Update one recurring invoice.
+// Supply the recurring invoice ID and only the fields to change, eg bdc.Set("endDate", "2020-12-31");
+// all other fields will be preserved. Zero and empty values are applied as is.
+// To guard against overwriting someone else's changes, include bdc.IfUnchanged(base).
+// Returns the updated recurring invoice as stored by Bill.com
+func (r recurringInvoiceResource) Update(id string, fields ...Field) (RecurringInvoice, error) {
+	var updated RecurringInvoice
+	changes, err := r.client.patchEntity(r.suffix, id, fields, &updated)
+	if err != nil {
+		if conflict, ok := err.(*ConflictError); ok {
+			return RecurringInvoice{}, conflict
+		}
+		if unreadable, ok := err.(*UnreadableResultError); ok {
+			return RecurringInvoice{}, unreadable
+		}
+		return RecurringInvoice{}, fmt.Errorf("Unable to update recurring invoice %v: %v", id, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Updated recurring invoice %s: %s", updated.ID, changes))
+	return updated, nil
+}
+
+// Deactivate one recurring invoice, stopping any future occurrences
+func (r recurringInvoiceResource) Deactivate(id string) error {
+	err := r.client.deactivateEntity(r.suffix, id)
+	if err != nil {
+		return fmt.Errorf("Unable to deactivate recurring invoice %v: %v", id, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Deactivated recurring invoice %s", id))
+	return nil
+}
@@ -10,7 +10,7 @@ type reports struct {
 // OpenInvoices are active and have an amount due greater than 0
 func (r reports) OpenInvoices() ([]Invoice, error) {
 	p := NewParameters()
-	p.AddFilter("isActive", "=", "1")
+	p.AddFilter("isActive", "=", Active)
 	p.AddFilter("amountDue", ">", 0)
 	p.AddSort("amountDue", 1)
 	inv, err := r.client.Invoice.All(p)
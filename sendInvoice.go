@@ -0,0 +1,75 @@
+package bdc
+
+import "fmt"
+
+const sendInvoiceEndpoint = "SendInvoice.json"
+
+// SendOptions for emailing an invoice from Bill.com
+type SendOptions struct {
+	To      []string // recipient email addresses; defaults to everyone returned by Customer.Contacts
+	Subject string
+	Message string
+	CopyMe  bool // send a copy to the logged-in user
+}
+
+// Send emails one invoice to its recipients via Bill.com
+func (r invoiceResource) Send(id string, opts SendOptions) error {
+	if id == "" {
+		return fmt.Errorf("Must provide invoice ID to send")
+	}
+	to := opts.To
+	if len(to) == 0 {
+		inv, err := r.Get(id)
+		if err != nil {
+			return fmt.Errorf("Unable to send invoice %v: unable to look up recipient: %v", id, err)
+		}
+		contacts, err := r.client.Customer.Contacts(inv.CustomerID)
+		if err != nil {
+			return fmt.Errorf("Unable to send invoice %v: unable to look up recipients: %v", id, err)
+		}
+		if len(contacts) == 0 {
+			return fmt.Errorf("Unable to send invoice %v: no recipients provided and customer %v has no email contacts", id, inv.CustomerID)
+		}
+		for _, contact := range contacts {
+			to = append(to, contact.Email)
+		}
+	}
+	values := map[string]interface{}{
+		"invoiceId": id,
+		"headers": map[string]interface{}{
+			"fromUserId":       r.client.userID,
+			"toEmailAddresses": to,
+			"ccMe":             opts.CopyMe,
+			"subject":          opts.Subject,
+		},
+		"content": map[string]interface{}{
+			"body": opts.Message,
+		},
+	}
+	err := r.client.mutate(sendInvoiceEndpoint, values, nil)
+	if err != nil {
+		return fmt.Errorf("Unable to send invoice %v: %v", id, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Sent invoice %s to %v", id, to))
+	return nil
+}
+
+// SendBatch emails each invoice provided, eg the invoices created by c.UpsertInvoicesFromCSV()
+// (see NewlyCreated, so that re-running an import does not email existing invoices again).
+// Continues past failures; the outcome for each invoice is recorded in history
+// and all failures are returned together
+func (r invoiceResource) SendBatch(invoices []Invoice, opts SendOptions) error {
+	var errSlice []string
+	for _, inv := range invoices {
+		err := r.Send(inv.ID, opts)
+		if err != nil {
+			r.client.writeToHistory(fmt.Sprintf("Failed to send invoice %s (number %s): %v", inv.ID, inv.InvoiceNumber, err))
+			errSlice = append(errSlice, err.Error())
+		}
+	}
+	err := handleErrSlice(errSlice)
+	if err != nil {
+		return fmt.Errorf("Unable to send %d of %d invoices:\n%v", len(errSlice), len(invoices), err)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package bdc
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// A Field is a single change to apply to an entity with a resource's Update method,
+// or a precondition on that update (see IfUnchanged)
+type Field struct {
+	name  string
+	value interface{}
+	base  interface{} // only set on preconditions
+}
+
+// Set returns a Field that sets the entity field with the JSON name provided (eg "isToBeEmailed") to value.
+// Unlike a zero-value struct field, a zero or empty value here is applied as is,
+// so Set("isToBeEmailed", false) or Set("poNumber", "") will clear the existing value
+func Set(name string, value interface{}) Field {
+	return Field{name: name, value: value}
+}
+
+// IfUnchanged returns a Field that makes an update conditional on the entity not having changed
+// since base, the version the update is based on (eg an Invoice returned by Get).
+// Immediately before writing, the updatedTime of base is compared with the server's;
+// if they differ, nothing is written and a *ConflictError holding both versions is returned
+func IfUnchanged(base interface{}) Field {
+	return Field{base: base}
+}
+
+// fields that are managed by Bill.com and can never be changed by a client
+var readOnlyFields = map[string]bool{
+	"entity":      true,
+	"id":          true,
+	"createdTime": true,
+	"updatedTime": true,
+}
+
+// patchEntity fetches the current version of an entity, applies only the fields provided,
+// and writes the merged entity back to Bill.com. All other fields are preserved as returned by the server.
+// Decodes the updated entity returned by the server into result, which must be a pointer.
+// Returns a JSON summary of the changes made, for use in history
+func (c *Client) patchEntity(suffix string, id string, fields []Field, result interface{}) (string, error) {
+	if id == "" {
+		return "", fmt.Errorf("Must provide ID to update")
+	}
+	var updates []Field
+	var base interface{}
+	for _, f := range fields {
+		if f.base != nil {
+			base = f.base
+			continue
+		}
+		updates = append(updates, f)
+	}
+	if len(updates) == 0 {
+		return "", fmt.Errorf("Must provide at least one field to update")
+	}
+	r, err := c.getOne(suffix, id)
+	if err != nil {
+		return "", fmt.Errorf("Unable to get current version to run update: %v", err)
+	}
+	var current confirmationResponse
+	err = json.Unmarshal(r, &current)
+	if err != nil || current.Data == nil {
+		return "", fmt.Errorf("Unable to read current version of %v to run update: %v", id, err)
+	}
+	if base != nil {
+		err = checkUnchanged(id, base, r, current.Data)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	changes := make(map[string]interface{})
+	for _, f := range updates {
+		if readOnlyFields[f.name] {
+			return "", fmt.Errorf("Field %q is read-only", f.name)
+		}
+		if _, ok := current.Data[f.name]; !ok {
+			return "", fmt.Errorf("Field %q does not exist on %v", f.name, current.Data["entity"])
+		}
+		current.Data[f.name] = f.value
+		changes[f.name] = f.value
+	}
+	summary, err := json.Marshal(maskSensitive(changes)) // written to history
+	if err != nil {
+		return "", fmt.Errorf("Unable to encode changes: %v", err)
+	}
+
+	err = c.updateEntity(suffix, current.Data, result)
+	if err != nil {
+		if unreadable, ok := err.(*UnreadableResultError); ok {
+			return string(summary), unreadable
+		}
+		return "", fmt.Errorf("Unable to make these changes: %s: %v", summary, err)
+	}
+	return string(summary), nil
+}
+
+// checkUnchanged returns a *ConflictError if the updatedTime of the server's current version of an entity
+// differs from that of base. The current version is decoded into the same type as base
+func checkUnchanged(id string, base interface{}, r []byte, current map[string]interface{}) error {
+	baseTime, err := updatedTime(base)
+	if err != nil {
+		return fmt.Errorf("Unable to check for conflicting changes: %v", err)
+	}
+	currentTime, _ := current["updatedTime"].(string)
+	same, err := sameTime(baseTime, currentTime)
+	if err != nil {
+		return fmt.Errorf("Unable to check for conflicting changes: %v", err)
+	}
+	if same {
+		return nil
+	}
+	currentVersion := reflect.New(reflect.TypeOf(base))
+	err = decodeEntity(r, currentVersion.Interface())
+	if err != nil {
+		return fmt.Errorf("Unable to read conflicting version of %v: %v", id, err)
+	}
+	return &ConflictError{
+		ID:                 id,
+		Base:               base,
+		Current:            currentVersion.Elem().Interface(),
+		BaseUpdatedTime:    baseTime,
+		CurrentUpdatedTime: currentTime,
+	}
+}
+
+// sameTime reports whether two times formatted according to TimeFormat are the same instant,
+// however many milliseconds digits each was written with
+func sameTime(a, b string) (bool, error) {
+	ta, err := time.Parse(TimeFormat, a)
+	if err != nil {
+		return false, fmt.Errorf("Unable to parse updatedTime %q: %v", a, err)
+	}
+	tb, err := time.Parse(TimeFormat, b)
+	if err != nil {
+		return false, fmt.Errorf("Unable to parse updatedTime %q: %v", b, err)
+	}
+	return ta.Equal(tb), nil
+}
+
+// read the updatedTime of any entity
+func updatedTime(entity interface{}) (string, error) {
+	b, err := json.Marshal(entity)
+	if err != nil {
+		return "", fmt.Errorf("Unable to encode entity: %v", err)
+	}
+	var m map[string]interface{}
+	err = json.Unmarshal(b, &m)
+	if err != nil {
+		return "", fmt.Errorf("Unable to decode entity: %v", err)
+	}
+	t, ok := m["updatedTime"].(string)
+	if !ok || t == "" {
+		return "", fmt.Errorf("Entity has no updatedTime")
+	}
+	return t, nil
+}
@@ -0,0 +1,173 @@
+package bdc
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type vendorCreditResponse struct {
+	Data []VendorCredit `json:"response_data"`
+}
+
+type vendorCreditResp struct {
+	Data VendorCredit `json:"response_data"`
+}
+
+// VendorCredit received from a vendor in Bill.com
+type VendorCredit struct {
+	Entity        string                 `json:"entity"`
+	ID            string                 `json:"id"`
+	CreatedTime   Timestamp              `json:"createdTime"`
+	UpdatedTime   Timestamp              `json:"updatedTime"`
+	IsActive      ActiveStatus           `json:"isActive"`
+	VendorID      string                 `json:"vendorId"`
+	RefNumber     string                 `json:"refNumber"`
+	CreditDate    Date                   `json:"creditDate"`
+	Amount        Money                  `json:"amount"`
+	AppliedAmount Money                  `json:"appliedAmount"`
+	Description   string                 `json:"description"`
+	LineItems     []VendorCreditLineItem `json:"vendorCreditLineItems"`
+}
+
+// VendorCreditLineItem on a Bill.com vendor credit
+type VendorCreditLineItem struct {
+	Entity           string `json:"entity"`
+	Amount           Money  `json:"amount"`
+	ChartOfAccountID string `json:"chartOfAccountId"`
+	ClassID          string `json:"actgClassId"`
+	LocationID       string `json:"locationId"`
+	Description      string `json:"description"`
+}
+
+// Remaining is the amount of the credit not yet applied
+func (c VendorCredit) Remaining() Money {
+	return c.Amount - c.AppliedAmount
+}
+
+type vendorCreditResource struct {
+	resourceFields
+}
+
+const applyVendorCreditEndpoint = "ApplyVendorCredit.json"
+
+// Get returns a single VendorCredit object
+func (r vendorCreditResource) Get(id string) (VendorCredit, error) {
+	b, err := r.client.getOne(r.suffix, id)
+	if err != nil {
+		return VendorCredit{}, fmt.Errorf("Unable to get vendor credit id %v: %v", id, err)
+	}
+	var goodResp vendorCreditResp
+	if err := json.Unmarshal(b, &goodResp); err != nil {
+		return VendorCredit{}, fmt.Errorf("Unable to read vendor credit id %v: %v", id, err)
+	}
+	return goodResp.Data, nil
+}
+
+// All vendor credits
+func (r vendorCreditResource) All(parameters ...*Parameters) ([]VendorCredit, error) {
+	results := r.client.getAll(r.suffix, parameters)
+
+	var retList []VendorCredit
+	var errSlice []string
+	for _, resp := range results {
+		if resp.err != nil {
+			errSlice = append(errSlice, fmt.Sprintf("Error on page %v: %v", resp.page, resp.err))
+		} else {
+			var goodResp vendorCreditResponse
+			if err := json.Unmarshal(resp.result, &goodResp); err != nil {
+				errSlice = append(errSlice, fmt.Sprintf("Unable to read page %v: %v", resp.page, err))
+				continue
+			}
+			retList = append(retList, goodResp.Data...)
+		}
+	}
+	err := handleErrSlice(errSlice)
+
+	return retList, err
+}
+
+// Since returns all vendor credits updated since the time provided.
+// If no additional params to provide, must pass nil explicitly
+func (r vendorCreditResource) Since(t time.Time, p *Parameters) ([]VendorCredit, error) {
+	if p == nil {
+		p = NewParameters()
+	}
+	p.AddFilter("updatedTime", ">", t.Format(TimeFormat))
+	results, err := r.client.VendorCredit.All(p)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get all vendor credits updated since %s: %v", t, err)
+	}
+	return results, nil
+}
+
+// Create a vendor credit.
+// Returns the new vendor credit as stored by Bill.com
+func (r vendorCreditResource) Create(credit VendorCredit) (VendorCredit, error) {
+	if credit.VendorID == "" {
+		return VendorCredit{}, fmt.Errorf("Unable to create vendor credit: must provide vendor ID")
+	}
+	credit.Entity = "VendorCredit"
+	var created VendorCredit
+	err := r.client.createEntity(r.suffix, credit, &created)
+	if err != nil {
+		if unreadable, ok := err.(*UnreadableResultError); ok {
+			return VendorCredit{}, unreadable
+		}
+		return VendorCredit{}, fmt.Errorf("Unable to create vendor credit for vendor %s in amount %v: %v", credit.VendorID, credit.Amount, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Created vendor credit %s for vendor %s in amount %v", created.ID, created.VendorID, created.Amount))
+	return created, nil
+}
+
+// Apply part or all of a vendor credit to specific bills of the same vendor.
+// Each allocation may not exceed the amount due on its bill,
+// and all allocations together may not exceed the credit remaining.
+// Returns the bills as stored by Bill.com after the credit is applied, so that AmountDue reflects it
+func (r vendorCreditResource) Apply(id string, allocations []BillAllocation) ([]Bill, error) {
+	credit, err := r.Get(id)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to apply vendor credit %s: %v", id, err)
+	}
+	if credit.IsActive != Active {
+		return nil, fmt.Errorf("Unable to apply vendor credit %s: credit is inactive", id)
+	}
+	_, err = r.client.validateBillAllocations(credit.VendorID, allocations)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to apply vendor credit %s: %v", id, err)
+	}
+	var total Money
+	for _, a := range allocations {
+		total += a.Amount
+	}
+	if total > credit.Remaining() {
+		return nil, fmt.Errorf("Unable to apply vendor credit %s: allocations total %v, which exceeds the %v remaining", id, total, credit.Remaining())
+	}
+
+	var applied []map[string]interface{}
+	for _, a := range allocations {
+		applied = append(applied, map[string]interface{}{
+			"billId": a.BillID,
+			"amount": a.Amount,
+		})
+	}
+	values := map[string]interface{}{
+		"vendorCreditId": id,
+		"bills":          applied,
+	}
+	err = r.client.mutate(applyVendorCreditEndpoint, values, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to apply vendor credit %s: %v", id, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Applied vendor credit %s to bills %v", id, allocations))
+
+	var updated []Bill
+	for _, a := range allocations {
+		bill, err := r.client.Bill.Get(a.BillID)
+		if err != nil {
+			return updated, fmt.Errorf("Applied vendor credit %s, but unable to get updated bill: %v", id, err)
+		}
+		updated = append(updated, bill)
+	}
+	return updated, nil
+}
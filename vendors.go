@@ -12,14 +12,14 @@ type vendorResponse struct {
 
 // Vendor in Bill.com
 type Vendor struct {
-	ID           string `json:"id"`
-	CreatedTime  string `json:"createdTime"`
-	UpdatedTime  string `json:"updatedTime"`
-	Entity       string `json:"entity"`
-	Name         string `json:"name"`
-	IsActive     string `json:"isActive"`
-	AccoutNumber string `json:"accNumber"`
-	Email        string `json:"email"`
+	ID           string       `json:"id"`
+	CreatedTime  Timestamp    `json:"createdTime"`
+	UpdatedTime  Timestamp    `json:"updatedTime"`
+	Entity       string       `json:"entity"`
+	Name         string       `json:"name"`
+	IsActive     ActiveStatus `json:"isActive"`
+	AccoutNumber string       `json:"accNumber"`
+	Email        string       `json:"email"`
 }
 
 type vendorResource struct {
@@ -37,7 +37,10 @@ func (r vendorResource) All(parameters ...*Parameters) ([]Vendor, error) {
 			errSlice = append(errSlice, fmt.Sprintf("Error on page %v: %v", resp.page, resp.err))
 		} else {
 			var goodResp vendorResponse
-			json.Unmarshal(resp.result, &goodResp)
+			if err := json.Unmarshal(resp.result, &goodResp); err != nil {
+				errSlice = append(errSlice, fmt.Sprintf("Unable to read page %v: %v", resp.page, err))
+				continue
+			}
 			retList = append(retList, goodResp.Data...)
 		}
 	}
@@ -59,3 +62,24 @@ func (r vendorResource) Since(t time.Time, p *Parameters) ([]Vendor, error) {
 	}
 	return vendors, nil
 }
+
+// Update one vendor.
+// Supply the vendor ID and only the fields to change, eg bdc.Set("email", "");
+// all other fields will be preserved. Zero and empty values are applied as is.
+// To guard against overwriting someone else's changes, include bdc.IfUnchanged(base).
+// Returns the updated vendor as stored by Bill.com
+func (r vendorResource) Update(id string, fields ...Field) (Vendor, error) {
+	var updated Vendor
+	changes, err := r.client.patchEntity(r.suffix, id, fields, &updated)
+	if err != nil {
+		if conflict, ok := err.(*ConflictError); ok {
+			return Vendor{}, conflict
+		}
+		if unreadable, ok := err.(*UnreadableResultError); ok {
+			return Vendor{}, unreadable
+		}
+		return Vendor{}, fmt.Errorf("Unable to update vendor %v: %v", id, err)
+	}
+	r.client.writeToHistory(fmt.Sprintf("Updated vendor %s: %s", updated.ID, changes))
+	return updated, nil
+}
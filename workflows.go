@@ -2,9 +2,10 @@ package bdc
 
 import (
 	"fmt"
-	"log"
+	"regexp"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -24,15 +25,6 @@ const (
 	AccountNumber                    = "account"
 )
 
-// Call this only on bill.com-provided date strings that are definitely dates (eg invoice and due dates)
-func mustParseDate(date string) time.Time {
-	t, err := time.Parse(DateFormat, date)
-	if err != nil {
-		log.Fatalf("Unable to parse date %s as date: %v", date, err)
-	}
-	return t
-}
-
 // ModifyAllInvoiceDates amends all of a customer's active invoices with invoice dates no more than 1 day old
 // and non-zero balance by the number of days specified.
 // If days is negative, invoice schedule will be moved forward by that many days
@@ -46,12 +38,15 @@ func (c *Client) ModifyAllInvoiceDates(identifier string, inputType CustomerIden
 		return fmt.Errorf("Unable to delay invoice schedule: no editable invoices exist - maybe they are inactive or all in the past?")
 	}
 
-	for idx, invoice := range editableInvoices {
-		update := Invoice{
-			ID:      invoice.ID,
-			DueDate: mustParseDate(invoice.DueDate).AddDate(0, 0, days).Format(DateFormat),
+	shiftDueDate := func(current Invoice) ([]Field, error) {
+		if current.DueDate.IsZero() {
+			return nil, fmt.Errorf("invoice %s has no due date", current.ID)
 		}
-		err := c.Invoice.Update(update)
+		return []Field{Set("dueDate", current.DueDate.AddDate(0, 0, days))}, nil
+	}
+	for idx, invoice := range editableInvoices {
+		// merge against the latest version so that a concurrent edit in the UI is not overwritten
+		_, err := c.Invoice.UpdateFunc(invoice.ID, shiftDueDate, 3)
 		if err != nil {
 			if idx == 0 { // failed on first invoice
 				return fmt.Errorf("Unable to modify any invoice dates: %v", err)
@@ -60,7 +55,7 @@ func (c *Client) ModifyAllInvoiceDates(identifier string, inputType CustomerIden
 			return fmt.Errorf("Unable to modify additional invoices: %v", err)
 		}
 	}
-	err = writeToHistory(fmt.Sprintf("Modified all future invoices for customer %v %s by %d days", inputType, identifier, days))
+	err = c.writeToHistory(fmt.Sprintf("Modified all future invoices for customer %v %s by %d days", inputType, identifier, days))
 	if err != nil {
 		return err
 	}
@@ -77,14 +72,14 @@ func (c *Client) getEditableInvoicesByCustomer(identifier string, inputType Cust
 		return nil, fmt.Errorf("Unable to get editable invoices: %v", err)
 	}
 	for _, invoice := range invoices {
-		if (invoice.IsActive == "1") &&
-			(mustParseDate(invoice.InvoiceDate).AddDate(0, 0, 2).After(now)) && // invoiceDate can be today or yesterday to account for time zone variations
+		if (invoice.IsActive == Active) &&
+			(invoice.InvoiceDate.AddDate(0, 0, 2).After(now)) && // invoiceDate can be today or yesterday to account for time zone variations
 			(invoice.AmountDue > 0) {
 			editableInvoices = append(editableInvoices, invoice)
 		}
 	}
 	sort.Slice(editableInvoices, func(i, j int) bool {
-		return editableInvoices[i].InvoiceDate < editableInvoices[j].InvoiceDate
+		return editableInvoices[i].InvoiceDate.Before(editableInvoices[j].InvoiceDate.Time)
 	})
 	return editableInvoices, nil
 }
@@ -93,7 +88,10 @@ func (c *Client) getEditableInvoicesByCustomer(identifier string, inputType Cust
 // Sums the amount due on all current active single-line-item invoices with invoice dates no more than 1 day old
 // and extends that balance over the number of newMonths provided
 // Assumptions: 1 invoice per month, all invoice line items have same value, class, location, and accounting item,
-// the last invoice in the series has the latest date
+// the last invoice in the series has the latest date.
+// New invoices are numbered after the last invoice in the series (eg "1001_ext1") and upserted only once every
+// existing invoice has been updated, so a re-run with the same newMonths counts any that already exist
+// as part of the same schedule and balance rather than stretching it again
 func (c *Client) StretchInvoiceSchedule(identifier string, inputType CustomerIdentifier, newMonths int) error {
 	editableInvoices, err := c.getEditableInvoicesByCustomer(identifier, inputType)
 	if err != nil {
@@ -101,7 +99,7 @@ func (c *Client) StretchInvoiceSchedule(identifier string, inputType CustomerIde
 	}
 	var singleLineInvoices []Invoice
 	for _, invoice := range editableInvoices {
-		if len(invoice.LineItems) == 1 {
+		if len(invoice.LineItems) == 1 && !extensionNumber.MatchString(invoice.InvoiceNumber) {
 			singleLineInvoices = append(singleLineInvoices, invoice)
 		}
 	}
@@ -113,21 +111,42 @@ func (c *Client) StretchInvoiceSchedule(identifier string, inputType CustomerIde
 		return fmt.Errorf("Unable to stretch invoice schedule: no editable invoices exist - maybe they have multiple line items or are in the past?")
 	}
 
-	var totalDue float64
+	anchorInvoice := singleLineInvoices[len(singleLineInvoices)-1] // latest invoice date
+	if anchorInvoice.DueDate.IsZero() {
+		return fmt.Errorf("Unable to stretch invoice schedule: invoice %s has no due date", anchorInvoice.ID)
+	}
+	additionalInvoices := newMonths - numInvoices
+
+	// extension invoices left by an earlier run are part of the balance, and are updated rather than created again
+	extensions := make(map[string]Invoice)
+	var totalDue Money
+	for _, invoice := range editableInvoices {
+		n, ok := extensionOf(anchorInvoice.InvoiceNumber, invoice.InvoiceNumber)
+		if !ok {
+			continue
+		}
+		if n > additionalInvoices {
+			return fmt.Errorf("Unable to stretch invoice schedule: extension invoice %s already exists beyond the %d months requested", invoice.InvoiceNumber, newMonths)
+		}
+		extensions[invoice.InvoiceNumber] = invoice
+		totalDue += invoice.AmountDue
+	}
 	for _, invoice := range singleLineInvoices {
 		totalDue += invoice.AmountDue
 	}
-	newDuePerInvoice := totalDue / float64(newMonths)
+	// split to the cent, so that the new schedule sums exactly to the balance
+	newDue := totalDue.Split(newMonths)
 
-	anchorInvoice := singleLineInvoices[len(singleLineInvoices)-1] // latest invoice date
-	newLineItem := anchorInvoice.LineItems[0]
-	newLineItem.Price = newDuePerInvoice
+	// each existing invoice keeps its own line item (and so its line item ID); new invoices copy the anchor's
+	lineItemFor := func(li InvoiceLineItem, month int) InvoiceLineItem {
+		li.Quantity = 1
+		li.Price = newDue[month]
+		li.Amount = newDue[month]
+		return li
+	}
 
 	for idx, invoice := range singleLineInvoices {
-		err := c.Invoice.Update(Invoice{
-			ID:        invoice.ID,
-			LineItems: []InvoiceLineItem{newLineItem},
-		})
+		_, err := c.Invoice.Update(invoice.ID, Set("invoiceLineItems", []InvoiceLineItem{lineItemFor(invoice.LineItems[0], idx)}))
 		if err != nil {
 			if idx == 0 {
 				return fmt.Errorf("Unable to stretch invoice schedule: unable to update any invoices: %v", err)
@@ -136,23 +155,27 @@ func (c *Client) StretchInvoiceSchedule(identifier string, inputType CustomerIde
 		}
 	}
 
-	additionalInvoices := newMonths - numInvoices
-
 	for i := 0; i < additionalInvoices; i++ {
-		newDate := mustParseDate(anchorInvoice.DueDate).AddDate(0, i+1, 0).Format(DateFormat) // add one month
+		newDate := anchorInvoice.DueDate.AddDate(0, i+1, 0).String() // add one month
+		invoiceNumber := anchorInvoice.InvoiceNumber + "_ext" + strconv.Itoa(i+1)
+		lineItem := anchorInvoice.LineItems[0].forNewInvoice()
+		if existing, ok := extensions[invoiceNumber]; ok && len(existing.LineItems) == 1 {
+			lineItem = existing.LineItems[0]
+		}
 		newInvoice, err := NewInvoice(
 			"default",
 			anchorInvoice.CustomerID,
-			anchorInvoice.InvoiceNumber+"_ext"+strconv.Itoa(i+1),
+			invoiceNumber,
 			newDate,
 			anchorInvoice.ClassID,
 			anchorInvoice.LocationID,
-			[]InvoiceLineItem{newLineItem},
+			[]InvoiceLineItem{lineItemFor(lineItem, numInvoices+i)},
 		)
 		if err != nil {
 			return fmt.Errorf("Unable to stretch invoice schedule: unable to populate new invoice for additional months: %v", err)
 		}
-		err = c.Invoice.Create(newInvoice)
+		// upsert so that an extension invoice number that already exists is updated rather than duplicated
+		_, err = c.Invoice.Upsert(newInvoice, UpdateExisting)
 		if err != nil {
 			if i == 0 { // failed on first invoice
 				return fmt.Errorf("Unable to stretch invoice schedule: unable to create any new invoices: %v", err)
@@ -161,7 +184,7 @@ func (c *Client) StretchInvoiceSchedule(identifier string, inputType CustomerIde
 			return fmt.Errorf("Unable to stretch invoice schedule: unable to create additional new invoices: %v", err)
 		}
 	}
-	err = writeToHistory(fmt.Sprintf("Stretched invoice schedule for customer %v %s to %d months", inputType, identifier, newMonths))
+	err = c.writeToHistory(fmt.Sprintf("Stretched invoice schedule for customer %v %s to %d months", inputType, identifier, newMonths))
 	if err != nil {
 		return err
 	}
@@ -169,6 +192,22 @@ func (c *Client) StretchInvoiceSchedule(identifier string, inputType CustomerIde
 
 }
 
+// invoice numbers created by StretchInvoiceSchedule end in "_ext" and the month of the extension, eg "1001_ext2"
+var extensionNumber = regexp.MustCompile(`_ext[0-9]+$`)
+
+// extensionOf returns the month n if invoiceNumber is anchorNumber + "_extN"
+func extensionOf(anchorNumber, invoiceNumber string) (int, bool) {
+	suffix := strings.TrimPrefix(invoiceNumber, anchorNumber+"_ext")
+	if suffix == invoiceNumber || !extensionNumber.MatchString("_ext"+suffix) {
+		return 0, false
+	}
+	n, err := strconv.Atoi(suffix)
+	if err != nil || n < 1 {
+		return 0, false
+	}
+	return n, true
+}
+
 func (c *Client) getInvoicesByCustomer(identifier string, inputType CustomerIdentifier) ([]Invoice, error) {
 	custID, err := c.identifyCustomer(identifier, inputType)
 	if err != nil {
@@ -196,7 +235,7 @@ func (c *Client) identifyCustomer(identifier string, inputType CustomerIdentifie
 		cID = identifier
 	case Name:
 		c.UpdateMappingFile(Customers)
-		m, err = getMapping(Customers)
+		m, err = getMapping(c.mappingsDir, Customers)
 		if err != nil {
 			return "", fmt.Errorf("Unable to identify customer: bad name mapping: %v", err)
 		}
@@ -206,7 +245,7 @@ func (c *Client) identifyCustomer(identifier string, inputType CustomerIdentifie
 		}
 	case AccountNumber:
 		c.UpdateMappingFile(CustomerAccountsID)
-		m, err = getMapping(CustomerAccountsID)
+		m, err = getMapping(c.mappingsDir, CustomerAccountsID)
 		if err != nil {
 			return "", fmt.Errorf("Unable to identify customer: bad account number mapping: %v", err)
 		}